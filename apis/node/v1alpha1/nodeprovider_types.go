@@ -0,0 +1,145 @@
+/*
+Copyright 2023 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	resourcev1alpha1 "github.com/nokia/k8s-ipam/apis/resource/common/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// NodeProviderSpec defines the container of the nodes of a provider that is served
+// by the generic node implementation. The name of the node provider is the provider
+// the nodes refer to, e.g. frr.example.com
+type NodeProviderSpec struct {
+	// Type of the provider, network or server
+	// +kubebuilder:validation:Enum=network;server
+	// +kubebuilder:default=network
+	Type string `json:"type,omitempty"`
+	// Model is the default model of the nodes, the node model of a model is
+	// named <provider>-<model> and lives in the namespace of the operator
+	// +kubebuilder:default=default
+	Model string `json:"model,omitempty"`
+	// Image is the default image of the nodes
+	Image string `json:"image"`
+	// Command of the container of the nodes
+	Command []string `json:"command,omitempty"`
+	// Args of the container of the nodes
+	Args []string `json:"args,omitempty"`
+	// Env of the container of the nodes
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Privileged runs the container of the nodes privileged
+	Privileged *bool `json:"privileged,omitempty"`
+	// ReadinessProbe of the container of the nodes
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+	// LivenessProbe of the container of the nodes
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+	// StartupProbe of the container of the nodes
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
+	// Volumes of the pod of the nodes
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+	// VolumeMounts of the container of the nodes
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// Resources are the default resource requirements of the container of the nodes,
+	// the constraints of the node config take precedence
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// StartupConfigPath is the directory the rendered startup config of the node
+	// is mounted on
+	// +kubebuilder:default="/etc/startup-config"
+	StartupConfigPath string `json:"startupConfigPath,omitempty"`
+	// Interfaces defines how the interfaces of the node model are named in the container
+	Interfaces *InterfaceNaming `json:"interfaces,omitempty"`
+	// Bootstrap defines the command that provisions the initial config of the nodes
+	Bootstrap *Bootstrap `json:"bootstrap,omitempty"`
+}
+
+// InterfaceNaming defines how the interfaces of the node model are named in the container.
+type InterfaceNaming struct {
+	// Template is a go text/template that renders the name of the interface in the
+	// container from the Name and the Index of the interface in the node model,
+	// e.g. eth{{ .Index }}. When empty, the name of the node model is used.
+	Template string `json:"template,omitempty"`
+	// Offset is added to the index of the interfaces, e.g. 1 to start at eth1
+	Offset int `json:"offset,omitempty"`
+	// MTU of the interfaces, when 0 the default mtu of the cni plugin is used
+	MTU int `json:"mtu,omitempty"`
+}
+
+// Bootstrap defines the command that provisions the initial config of the nodes.
+type Bootstrap struct {
+	// Command is executed in the container of the node once the node is ready.
+	// Every element is a go text/template that is rendered with the startup
+	// config input of the node, e.g. the Name and the IPs of the node.
+	Command []string `json:"command"`
+}
+
+// NodeProviderStatus defines the observed state of NodeProvider
+type NodeProviderStatus struct {
+	// ConditionedStatus provides the status of the NodeProvider using conditions
+	// - a ready condition indicates the provider is registered
+	resourcev1alpha1.ConditionedStatus `json:",inline" yaml:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,categories={nephio,inv}
+//+kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+//+kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.type"
+//+kubebuilder:printcolumn:name="IMAGE",type="string",JSONPath=".spec.image"
+
+// NodeProvider is the Schema for the nodeprovider API
+type NodeProvider struct {
+	metav1.TypeMeta   `json:",inline" yaml:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	Spec   NodeProviderSpec   `json:"spec,omitempty" yaml:"spec,omitempty"`
+	Status NodeProviderStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NodeProviderList contains a list of NodeProviders
+type NodeProviderList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Items           []NodeProvider `json:"items" yaml:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeProvider{}, &NodeProviderList{})
+}
+
+var (
+	NodeProviderKind             = reflect.TypeOf(NodeProvider{}).Name()
+	NodeProviderGroupKind        = schema.GroupKind{Group: Group, Kind: NodeProviderKind}.String()
+	NodeProviderKindAPIVersion   = NodeProviderKind + "." + GroupVersion.String()
+	NodeProviderGroupVersionKind = GroupVersion.WithKind(NodeProviderKind)
+)
+
+// GetCondition returns the condition based on the condition kind
+func (r *NodeProvider) GetCondition(t resourcev1alpha1.ConditionType) resourcev1alpha1.Condition {
+	return r.Status.GetCondition(t)
+}
+
+// SetConditions sets the conditions on the resource. it allows for 0, 1 or more conditions
+// to be set at once
+func (r *NodeProvider) SetConditions(c ...resourcev1alpha1.Condition) {
+	r.Status.SetConditions(c...)
+}
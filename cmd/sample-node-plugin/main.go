@@ -0,0 +1,75 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// sample-node-plugin serves the sample node provider as an out of process plugin
+package main
+
+import (
+	"flag"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/plugin"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/plugin/sample"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var (
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func main() {
+	var address string
+	flag.StringVar(&address, "address", "unix:///var/run/network-node/plugins/sample.sock",
+		"The address the plugin serves on, unix://<path> for a unix socket or <host>:<port> for tcp.")
+	opts := zap.Options{
+		Development: true,
+	}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	network := "tcp"
+	if strings.HasPrefix(address, "unix://") {
+		network = "unix"
+		address = strings.TrimPrefix(address, "unix://")
+		// remove the socket of a previous run
+		if err := os.RemoveAll(address); err != nil {
+			setupLog.Error(err, "cannot remove socket", "address", address)
+			os.Exit(1)
+		}
+	}
+	lis, err := net.Listen(network, address)
+	if err != nil {
+		setupLog.Error(err, "cannot listen", "address", address)
+		os.Exit(1)
+	}
+
+	p, err := sample.New()
+	if err != nil {
+		setupLog.Error(err, "cannot initialize plugin")
+		os.Exit(1)
+	}
+
+	setupLog.Info("serving plugin", "network", network, "address", address)
+	if err := plugin.Serve(ctrl.SetupSignalHandler(), lis, p); err != nil {
+		setupLog.Error(err, "problem serving plugin")
+		os.Exit(1)
+	}
+}
@@ -27,4 +27,6 @@ type ControllerConfig struct {
 	Poll         time.Duration
 	Copts        controller.Options
 	Noderegistry node.NodeRegistry
+	// MaxConcurrentInitialConfigs bounds the concurrent initial config sessions per provider
+	MaxConcurrentInitialConfigs int
 }
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/nephio-project/nephio/controllers/pkg/resource"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	resourcev1alpha1 "github.com/nokia/k8s-ipam/apis/resource/common/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// handleDelete tears down the node before the finalizer is removed. The teardown
+// hook of the provider runs while the workload is ready, after which the workload,
+// the nads, the startup config and the pvcs are deleted in order. The returned message
+// reports the step that is in progress, done is true when the resources are deleted.
+func (r *reconciler) handleDelete(ctx context.Context, cr *invv1alpha1.Node) (string, bool, error) {
+	l := log.FromContext(ctx)
+	// when the provider is no longer registered the workload is assumed to be a pod,
+	// other workloads are garbage collected through their owner reference
+	var w workload = podWorkload{}
+	n, err := r.nodeRegistry.NewNodeOfProvider(cr.Spec.Provider, r.Client, r.scheme)
+	if err == nil {
+		w = getWorkload(n.GetProviderType(ctx))
+	}
+	obj := w.newObject()
+	if err := r.Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: cr.GetNamespace()}, obj); err != nil {
+		if resource.IgnoreNotFound(err) != nil {
+			return "", false, err
+		}
+		obj = nil
+	}
+
+	if obj != nil {
+		msg := fmt.Sprintf("waiting for %s termination", strings.ToLower(w.kind()))
+		if obj.GetDeletionTimestamp() != nil {
+			return msg, false, nil
+		}
+		// the teardown only runs when the node is reachable and the provider is
+		// registered, an unregistered provider cannot block the deletion of the node
+		if podIPs, _, _, ready := w.getStatus(obj); ready {
+			if n == nil {
+				l.Info("skip teardown, provider not registered", "provider", cr.Spec.Provider, "err", err.Error())
+			} else {
+				l.Info("teardown node")
+				if err := n.Teardown(ctx, cr, podIPs); err != nil {
+					return "", false, err
+				}
+			}
+		}
+		l.Info("delete workload", "kind", w.kind(), "name", obj.GetName())
+		if err := r.Delete(ctx, obj); resource.IgnoreNotFound(err) != nil {
+			return "", false, err
+		}
+		return msg, false, nil
+	}
+
+	// deleting all the resources of a kind is the same as deleting the
+	// resources that are not used by the node
+	lists := []client.ObjectList{}
+	if os.Getenv("ENABLE_NAD") == "true" {
+		lists = append(lists, &nadv1.NetworkAttachmentDefinitionList{})
+	}
+	lists = append(lists,
+		&corev1.ConfigMapList{},
+		&corev1.PersistentVolumeClaimList{},
+	)
+	for _, objs := range lists {
+		if err := r.deleteUnusedResources(ctx, cr, objs, nil); err != nil {
+			return "", false, err
+		}
+	}
+	return "", true, nil
+}
+
+// deleting returns the condition of a node that is being deleted
+func deleting(msg string) resourcev1alpha1.Condition {
+	c := resourcev1alpha1.NotReady(msg)
+	c.Reason = string(resourcev1alpha1.ConditionReasonDeleting)
+	return c
+}
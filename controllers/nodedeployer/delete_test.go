@@ -0,0 +1,100 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	"github.com/nephio-project/nephio/controllers/pkg/resource"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileDeleteUnregisteredProvider(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := invv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	now := metav1.Now()
+	cr := &invv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "node1",
+			UID:               types.UID("uid1"),
+			DeletionTimestamp: &now,
+			Finalizers:        []string{finalizer},
+		},
+		Spec: invv1alpha1.NodeSpec{
+			Provider: testProvider,
+		},
+	}
+	pod := getTestPod(cr)
+	pod.Status = corev1.PodStatus{
+		Conditions: []corev1.PodCondition{
+			{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+		},
+		ContainerStatuses: []corev1.ContainerStatus{
+			{Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+		},
+		PodIPs: []corev1.PodIP{{IP: "10.0.0.1"}},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(cr, pod).
+		WithStatusSubresource(&invv1alpha1.Node{}).
+		Build()
+
+	// the provider of the node is not registered, e.g. its node provider was deleted
+	r := &reconciler{
+		Client:               c,
+		scheme:               s,
+		finalizer:            resource.NewAPIFinalizer(c, finalizer),
+		nodeRegistry:         node.NewNodeRegistry(),
+		recorder:             record.NewFakeRecorder(100),
+		poll:                 defaultPoll,
+		initialConfigLimiter: newProviderLimiter(1),
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "node1"}}
+	// the first reconcile deletes the ready pod without a teardown, the second one
+	// removes the finalizer
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("reconcile %d: unexpected error: %s", i, err)
+		}
+	}
+
+	if err := c.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); resource.IgnoreNotFound(err) != nil || err == nil {
+		t.Errorf("want pod deleted, got: %v", err)
+	}
+	if err := c.Get(context.Background(), req.NamespacedName, &invv1alpha1.Node{}); resource.IgnoreNotFound(err) != nil || err == nil {
+		t.Errorf("want node deleted, got: %v", err)
+	}
+}
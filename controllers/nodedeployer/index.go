@@ -0,0 +1,141 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"os"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// nodeConfigIndexKey indexes the nodes by the name of the explicitly referenced node config
+	nodeConfigIndexKey = "spec.nodeConfig.name"
+	// providerIndexKey indexes the nodes by provider
+	providerIndexKey = "spec.provider"
+)
+
+// setupIndexes indexes the nodes such that the watches can map the resources that
+// are referenced by the nodes back to the nodes.
+func setupIndexes(ctx context.Context, mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &invv1alpha1.Node{}, nodeConfigIndexKey, func(o client.Object) []string {
+		cr, ok := o.(*invv1alpha1.Node)
+		if !ok || cr.Spec.NodeConfig == nil || cr.Spec.NodeConfig.Name == "" {
+			return nil
+		}
+		return []string{cr.Spec.NodeConfig.Name}
+	}); err != nil {
+		return err
+	}
+	return mgr.GetFieldIndexer().IndexField(ctx, &invv1alpha1.Node{}, providerIndexKey, func(o client.Object) []string {
+		cr, ok := o.(*invv1alpha1.Node)
+		if !ok {
+			return nil
+		}
+		return []string{cr.Spec.Provider}
+	})
+}
+
+// getNodesOfNodeConfig returns the nodes that use the node config as one of their
+// node config layers, which are the nodes referencing the node config explicitly and
+// the nodes of the provider that match the node config by name, through the default
+// node config or through the node selector of the node config.
+func getNodesOfNodeConfig(ctx context.Context, c client.Client, nc *invv1alpha1.NodeConfig) ([]types.NamespacedName, error) {
+	nodes := []types.NamespacedName{}
+
+	refNodes := &invv1alpha1.NodeList{}
+	if err := c.List(ctx, refNodes, client.MatchingFields{nodeConfigIndexKey: nc.GetName()}); err != nil {
+		return nil, err
+	}
+	for _, n := range refNodes.Items {
+		nodes = append(nodes, types.NamespacedName{Namespace: n.GetNamespace(), Name: n.GetName()})
+	}
+
+	// the node configs in the namespace of the operator apply to the nodes in all namespaces
+	opts := []client.ListOption{
+		client.MatchingFields{providerIndexKey: nc.Spec.Provider},
+	}
+	if nc.GetNamespace() != os.Getenv("POD_NAMESPACE") {
+		opts = append(opts, client.InNamespace(nc.GetNamespace()))
+	}
+	providerNodes := &invv1alpha1.NodeList{}
+	if err := c.List(ctx, providerNodes, opts...); err != nil {
+		return nil, err
+	}
+	for _, n := range providerNodes.Items {
+		n := n
+		applies, err := node.AppliesToNode(nc, &n)
+		if err != nil {
+			return nil, err
+		}
+		if applies {
+			nodes = append(nodes, types.NamespacedName{Namespace: n.GetNamespace(), Name: n.GetName()})
+		}
+	}
+	return nodes, nil
+}
+
+// getNodesOfProvider returns the nodes of the provider, when the namespace is not empty
+// only the nodes in the namespace are returned.
+func getNodesOfProvider(ctx context.Context, c client.Client, provider, namespace string) ([]types.NamespacedName, error) {
+	opts := []client.ListOption{
+		client.MatchingFields{providerIndexKey: provider},
+	}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	nl := &invv1alpha1.NodeList{}
+	if err := c.List(ctx, nl, opts...); err != nil {
+		return nil, err
+	}
+	nodes := make([]types.NamespacedName, 0, len(nl.Items))
+	for _, n := range nl.Items {
+		nodes = append(nodes, types.NamespacedName{Namespace: n.GetNamespace(), Name: n.GetName()})
+	}
+	return nodes, nil
+}
+
+// getNodesOfNodeConfigs returns the nodes in the namespace that resolve to one of the
+// node configs selected by the filter.
+func getNodesOfNodeConfigs(ctx context.Context, c client.Client, namespace string, filter func(nc *invv1alpha1.NodeConfig) bool) ([]types.NamespacedName, error) {
+	ncl := &invv1alpha1.NodeConfigList{}
+	if err := c.List(ctx, ncl, client.InNamespace(os.Getenv("POD_NAMESPACE"))); err != nil {
+		return nil, err
+	}
+	nodes := []types.NamespacedName{}
+	for _, nc := range ncl.Items {
+		nc := nc
+		if !filter(&nc) {
+			continue
+		}
+		ncNodes, err := getNodesOfNodeConfig(ctx, c, &nc)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range ncNodes {
+			if n.Namespace == namespace {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	return nodes, nil
+}
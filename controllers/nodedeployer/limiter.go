@@ -0,0 +1,69 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"sync"
+)
+
+// providerLimiter bounds the number of concurrent sessions per provider, independent
+// of the number of concurrent reconciles. A limit of 0 or less means unbounded.
+type providerLimiter struct {
+	m     sync.Mutex
+	limit int
+	sems  map[string]chan struct{}
+}
+
+func newProviderLimiter(limit int) *providerLimiter {
+	return &providerLimiter{
+		limit: limit,
+		sems:  map[string]chan struct{}{},
+	}
+}
+
+// acquire blocks until a session of the provider is available or the context is done
+func (r *providerLimiter) acquire(ctx context.Context, provider string) error {
+	if r.limit <= 0 {
+		return nil
+	}
+	select {
+	case r.get(provider) <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release releases a session of the provider that was acquired before
+func (r *providerLimiter) release(provider string) {
+	if r.limit <= 0 {
+		return
+	}
+	<-r.get(provider)
+}
+
+func (r *providerLimiter) get(provider string) chan struct{} {
+	r.m.Lock()
+	defer r.m.Unlock()
+	sem, ok := r.sems[provider]
+	if !ok {
+		sem = make(chan struct{}, r.limit)
+		r.sems[provider] = sem
+	}
+	return sem
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/nephio-project/nephio/controllers/pkg/resource"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	resourcev1alpha1 "github.com/nokia/k8s-ipam/apis/resource/common/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// linkConditionType is the condition of the link that records the cni plugin
+	// that implements the link
+	linkConditionType = resourcev1alpha1.ConditionType("Implementation")
+	vxlanDstPort      = 4789
+	maxVNI            = 1<<24 - 1
+)
+
+// linkImplementation defines the cni plugin that implements a link.
+type linkImplementation struct {
+	cniType string
+	// vni of the vxlan tunnel of a link between endpoints on different hosts
+	vni int
+	// remote is the ip of the host of the peer endpoint of a vxlan tunnel
+	remote string
+	// hosts of the endpoints of the link in the order of the endpoints, empty
+	// when an endpoint is not scheduled
+	hosts []string
+	// conflict is the link between the same hosts that derives the same vni
+	conflict string
+}
+
+// getLinkImplementation returns the implementation of the link seen from the interface
+// of the node. The wire plugin only connects endpoints on the same host, hence a link
+// between endpoints on different hosts uses a point to point vxlan tunnel. Until both
+// endpoints are scheduled the wire plugin is used.
+func (r *reconciler) getLinkImplementation(ctx context.Context, cr *invv1alpha1.Node, itfceName string, link *invv1alpha1.Link) (*linkImplementation, error) {
+	hosts, err := r.getLinkHosts(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	impl := &linkImplementation{cniType: nad.WirePluginType, hosts: hosts}
+	if !isCrossHost(hosts) {
+		return impl, nil
+	}
+	remoteHost := ""
+	for i, ep := range link.Spec.Endpoints {
+		if ep.NodeName != cr.GetName() || ep.InterfaceName != itfceName {
+			remoteHost = hosts[i]
+		}
+	}
+	remote, err := r.getHostIP(ctx, remoteHost)
+	if err != nil {
+		return nil, err
+	}
+	impl.cniType = nad.VxlanPluginType
+	impl.vni = getVNI(link)
+	impl.remote = remote
+	if impl.conflict, err = r.getVNIConflict(ctx, link, impl); err != nil {
+		return nil, err
+	}
+	return impl, nil
+}
+
+// getLinkHosts returns the hosts of the endpoints of the link in the order of the
+// endpoints, the host is empty when the node of the endpoint is not scheduled.
+func (r *reconciler) getLinkHosts(ctx context.Context, link *invv1alpha1.Link) ([]string, error) {
+	hosts := make([]string, 0, len(link.Spec.Endpoints))
+	for _, ep := range link.Spec.Endpoints {
+		n := &invv1alpha1.Node{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: link.GetNamespace(), Name: ep.NodeName}, n); resource.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		hosts = append(hosts, n.GetAnnotations()[node.HostKey])
+	}
+	return hosts, nil
+}
+
+// isCrossHost returns true when both endpoints are scheduled on different hosts.
+func isCrossHost(hosts []string) bool {
+	return len(hosts) == 2 && hosts[0] != "" && hosts[1] != "" && hosts[0] != hosts[1]
+}
+
+// getVNIConflict returns the name of another link between the same hosts with the same
+// vni. Since the vni is derived from the name of the link the vxlan tunnels of such links
+// cannot be told apart, which is reported in the status of the link.
+func (r *reconciler) getVNIConflict(ctx context.Context, link *invv1alpha1.Link, impl *linkImplementation) (string, error) {
+	links := &invv1alpha1.LinkList{}
+	if err := r.List(ctx, links); err != nil {
+		return "", errors.Wrap(err, errListLinks)
+	}
+	for _, l := range links.Items {
+		l := l
+		if l.GetNamespace() == link.GetNamespace() && l.GetName() == link.GetName() {
+			continue
+		}
+		if getVNI(&l) != impl.vni {
+			continue
+		}
+		hosts, err := r.getLinkHosts(ctx, &l)
+		if err != nil {
+			return "", err
+		}
+		if !isCrossHost(hosts) {
+			continue
+		}
+		if (hosts[0] == impl.hosts[0] && hosts[1] == impl.hosts[1]) || (hosts[0] == impl.hosts[1] && hosts[1] == impl.hosts[0]) {
+			return types.NamespacedName{Namespace: l.GetNamespace(), Name: l.GetName()}.String(), nil
+		}
+	}
+	return "", nil
+}
+
+// getHostIP returns the internal ip of the host, which is the vtep of the vxlan
+// tunnels of the host.
+func (r *reconciler) getHostIP(ctx context.Context, host string) (string, error) {
+	h := &corev1.Node{}
+	if err := r.Get(ctx, types.NamespacedName{Name: host}, h); err != nil {
+		return "", err
+	}
+	for _, addr := range h.Status.Addresses {
+		if addr.Type == corev1.NodeInternalIP {
+			return addr.Address, nil
+		}
+	}
+	return "", fmt.Errorf("host %s has no internal ip", host)
+}
+
+// getVNI returns the vni of the link, which is derived from the name of the link such
+// that both endpoints select the same vni without coordination.
+func getVNI(link *invv1alpha1.Link) int {
+	h := fnv.New32a()
+	h.Write([]byte(types.NamespacedName{Namespace: link.GetNamespace(), Name: link.GetName()}.String()))
+	return int(h.Sum32()%maxVNI) + 1
+}
+
+// isWireNetworkAttachmentDefinition returns true when the nad implements the link of
+// the interface with the wire plugin.
+func isWireNetworkAttachmentDefinition(n *nadv1.NetworkAttachmentDefinition) (bool, error) {
+	plugins, err := nad.GetPluginConfigs(n)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range plugins {
+		if p.GetType() == nad.WirePluginType {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getVxlanNetworkAttachmentDefinition returns the nad with the wire plugin replaced by
+// a vxlan plugin, which keeps the interface name and the mtu of the wire plugin.
+func getVxlanNetworkAttachmentDefinition(n *nadv1.NetworkAttachmentDefinition, impl *linkImplementation) (*nadv1.NetworkAttachmentDefinition, error) {
+	plugins, err := nad.GetPluginConfigs(n)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range plugins {
+		wire, ok := p.(*nad.WirePlugin)
+		if !ok {
+			continue
+		}
+		plugins[i] = nad.VxlanPlugin{
+			PluginCniType: nad.PluginCniType{
+				Type: nad.VxlanPluginType,
+			},
+			InterfaceName: wire.InterfaceName,
+			VNI:           impl.vni,
+			Remote:        impl.remote,
+			DstPort:       vxlanDstPort,
+			MTU:           wire.MTU,
+		}
+	}
+	b, err := nad.GetNadConfig(plugins)
+	if err != nil {
+		return nil, err
+	}
+	newNad := n.DeepCopy()
+	newNad.Spec.Config = string(b)
+	return newNad, nil
+}
+
+// setLinkStatus records the implementation of the link in the status of the link
+// when it changed.
+func (r *reconciler) setLinkStatus(ctx context.Context, link *invv1alpha1.Link, impl *linkImplementation) error {
+	c := getLinkCondition(impl)
+	old := link.GetCondition(linkConditionType)
+	if old.Status == c.Status && old.Reason == c.Reason && old.Message == c.Message {
+		return nil
+	}
+	newLink := link.DeepCopy()
+	newLink.SetConditions(c)
+	if err := r.Status().Patch(ctx, newLink, client.MergeFrom(link)); err != nil {
+		return err
+	}
+	link.Status = newLink.Status
+	return nil
+}
+
+// getLinkCondition returns the implementation condition of the link. The wire plugin
+// of a link with an endpoint that is not scheduled is provisional, the workloads of the
+// endpoints are recreated with a vxlan tunnel when they are scheduled on different hosts.
+func getLinkCondition(impl *linkImplementation) resourcev1alpha1.Condition {
+	c := resourcev1alpha1.Condition{Condition: metav1.Condition{
+		Type:               string(linkConditionType),
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+	}}
+	switch {
+	case impl.cniType == nad.VxlanPluginType && impl.conflict != "":
+		c.Status = metav1.ConditionFalse
+		c.Reason = "VniConflict"
+		c.Message = fmt.Sprintf("endpoints on hosts %s and %s, vni %d is also used by link %s", impl.hosts[0], impl.hosts[1], impl.vni, impl.conflict)
+	case impl.cniType == nad.VxlanPluginType:
+		c.Reason = "Vxlan"
+		c.Message = fmt.Sprintf("endpoints on hosts %s and %s, vni %d", impl.hosts[0], impl.hosts[1], impl.vni)
+	case len(impl.hosts) == 2 && impl.hosts[0] != "" && impl.hosts[0] == impl.hosts[1]:
+		c.Reason = "Wire"
+		c.Message = fmt.Sprintf("endpoints on host %s", impl.hosts[0])
+	default:
+		c.Status = metav1.ConditionFalse
+		c.Reason = "Provisional"
+		c.Message = "endpoints not scheduled, the wire implementation is replaced by vxlan when the endpoints are scheduled on different hosts"
+	}
+	return c
+}
+
+// setCrossHostRevision adds the config of the nads to the revision hash of the workload,
+// since the networks of a workload are only attached when it is created, which replaces
+// the workload when a link changes between wire and vxlan. The workload is pinned to
+// the recorded host of the node, such that a replaced workload does not move to another
+// host, which would change the implementation of the links again.
+func setCrossHostRevision(w workload, obj client.Object, nads []*nadv1.NetworkAttachmentDefinition, host string) error {
+	configs := make([]string, 0, len(nads))
+	for _, n := range nads {
+		configs = append(configs, n.Spec.Config)
+	}
+	hash, err := node.GetHash([]any{obj.GetAnnotations()[invv1alpha1.RevisionHash], configs})
+	if err != nil {
+		return err
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[invv1alpha1.RevisionHash] = hash
+	obj.SetAnnotations(annotations)
+	if host == "" {
+		return nil
+	}
+	return w.setHost(obj, host)
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetLinkedNetworkAttachmentDefinitions(t *testing.T) {
+	link := &invv1alpha1.Link{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "node1-node2",
+		},
+		Spec: invv1alpha1.LinkSpec{
+			Endpoints: []invv1alpha1.LinkEndpoint{
+				{NodeName: "node1", InterfaceName: "e1-1"},
+				{NodeName: "node2", InterfaceName: "e1-1"},
+			},
+		},
+	}
+	vni := getVNI(link)
+
+	cases := map[string]struct {
+		hosts         map[string]string
+		wantConfig    string
+		wantCrossHost bool
+		wantStatus    metav1.ConditionStatus
+		wantReason    string
+		wantMessage   string
+	}{
+		"NotScheduled": {
+			hosts: map[string]string{
+				"node1": "host1",
+			},
+			wantConfig:  `{"cniVersion":"0.3.1","plugins":[{"interfaceName":"eth1","mtu":9000,"type":"wire"}]}`,
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  "Provisional",
+			wantMessage: "endpoints not scheduled, the wire implementation is replaced by vxlan when the endpoints are scheduled on different hosts",
+		},
+		"SameHost": {
+			hosts: map[string]string{
+				"node1": "host1",
+				"node2": "host1",
+			},
+			wantConfig:  `{"cniVersion":"0.3.1","plugins":[{"interfaceName":"eth1","mtu":9000,"type":"wire"}]}`,
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  "Wire",
+			wantMessage: "endpoints on host host1",
+		},
+		"CrossHost": {
+			hosts: map[string]string{
+				"node1": "host1",
+				"node2": "host2",
+			},
+			wantConfig:    fmt.Sprintf(`{"cniVersion":"0.3.1","plugins":[{"dstPort":4789,"interfaceName":"eth1","mtu":9000,"remote":"172.18.0.2","type":"vxlan","vni":%d}]}`, vni),
+			wantCrossHost: true,
+			wantStatus:    metav1.ConditionTrue,
+			wantReason:    "Vxlan",
+			wantMessage:   fmt.Sprintf("endpoints on hosts host1 and host2, vni %d", vni),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			if err := invv1alpha1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+
+			objs := []client.Object{link.DeepCopy()}
+			for i, name := range []string{"node1", "node2"} {
+				n := &invv1alpha1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      name,
+					},
+				}
+				if host, ok := tc.hosts[name]; ok {
+					n.SetAnnotations(map[string]string{node.HostKey: host})
+				}
+				objs = append(objs,
+					n,
+					&corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("host%d", i+1)},
+						Status: corev1.NodeStatus{
+							Addresses: []corev1.NodeAddress{
+								{Type: corev1.NodeHostName, Address: fmt.Sprintf("host%d", i+1)},
+								{Type: corev1.NodeInternalIP, Address: fmt.Sprintf("172.18.0.%d", i+1)},
+							},
+						},
+					},
+				)
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(s).
+				WithObjects(objs...).
+				WithStatusSubresource(&invv1alpha1.Link{}).
+				Build()
+			r := &reconciler{Client: c, scheme: s}
+
+			cr := &invv1alpha1.Node{}
+			if err := c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "node1"}, cr); err != nil {
+				t.Fatal(err)
+			}
+			nads, err := node.GetWireNetworkAttachmentDefinitions(cr, []node.Interface{
+				{Name: "e1-1", ContainerName: "eth1", MTU: 9000},
+				{Name: "e1-2", ContainerName: "eth2", MTU: 9000},
+				{Name: "e1-3", ContainerName: "eth3", Plugin: nad.MacvlanPlugin{
+					PluginCniType: nad.PluginCniType{Type: nad.MacvlanPluginType},
+					Master:        "eth1",
+				}},
+			}, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, crossHost, err := r.getLinkedNetworkAttachmentDefinitions(context.Background(), cr, nads)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			// only the linked interface and the interface that does not use the wire
+			// plugin get a nad
+			if len(got) != 2 {
+				t.Fatalf("want 2 nads, got: %d", len(got))
+			}
+			if diff := cmp.Diff(tc.wantConfig, got[0].Spec.Config); diff != "" {
+				t.Errorf("config -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(`{"cniVersion":"0.3.1","plugins":[{"master":"eth1","type":"macvlan"}]}`, got[1].Spec.Config); diff != "" {
+				t.Errorf("macvlan config -want, +got:\n%s", diff)
+			}
+			if crossHost != tc.wantCrossHost {
+				t.Errorf("want cross host %t, got: %t", tc.wantCrossHost, crossHost)
+			}
+
+			gotLink := &invv1alpha1.Link{}
+			if err := c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: link.GetName()}, gotLink); err != nil {
+				t.Fatal(err)
+			}
+			cond := gotLink.GetCondition(linkConditionType)
+			if cond.Status != tc.wantStatus || cond.Reason != tc.wantReason || cond.Message != tc.wantMessage {
+				t.Errorf("want condition %s/%s/%q, got: %s/%s/%q", tc.wantStatus, tc.wantReason, tc.wantMessage, cond.Status, cond.Reason, cond.Message)
+			}
+		})
+	}
+}
+
+func TestGetVNIConflict(t *testing.T) {
+	// the names of the links derive the same vni
+	link := &invv1alpha1.Link{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "link3838"},
+		Spec: invv1alpha1.LinkSpec{
+			Endpoints: []invv1alpha1.LinkEndpoint{
+				{NodeName: "node1", InterfaceName: "e1-1"},
+				{NodeName: "node2", InterfaceName: "e1-1"},
+			},
+		},
+	}
+	other := &invv1alpha1.Link{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "link14764"},
+		Spec: invv1alpha1.LinkSpec{
+			Endpoints: []invv1alpha1.LinkEndpoint{
+				{NodeName: "node3", InterfaceName: "e1-1"},
+				{NodeName: "node4", InterfaceName: "e1-1"},
+			},
+		},
+	}
+	if getVNI(link) != getVNI(other) {
+		t.Fatalf("want the same vni, got: %d and %d", getVNI(link), getVNI(other))
+	}
+
+	cases := map[string]struct {
+		otherHosts   []string
+		wantConflict string
+		wantReason   string
+	}{
+		"SameHosts": {
+			otherHosts:   []string{"host2", "host1"},
+			wantConflict: "default/link14764",
+			wantReason:   "VniConflict",
+		},
+		"OtherHosts": {
+			otherHosts: []string{"host1", "host3"},
+			wantReason: "Vxlan",
+		},
+		"OtherNotScheduled": {
+			otherHosts: []string{"host1", ""},
+			wantReason: "Vxlan",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			if err := invv1alpha1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+
+			objs := []client.Object{
+				link.DeepCopy(),
+				other.DeepCopy(),
+				&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "host2"},
+					Status: corev1.NodeStatus{
+						Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "172.18.0.2"}},
+					},
+				},
+			}
+			hosts := map[string]string{"node1": "host1", "node2": "host2", "node3": tc.otherHosts[0], "node4": tc.otherHosts[1]}
+			for name, host := range hosts {
+				n := &invv1alpha1.Node{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+				if host != "" {
+					n.SetAnnotations(map[string]string{node.HostKey: host})
+				}
+				objs = append(objs, n)
+			}
+			c := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+			r := &reconciler{Client: c, scheme: s}
+
+			cr := &invv1alpha1.Node{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1"}}
+			impl, err := r.getLinkImplementation(context.Background(), cr, "e1-1", link)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if impl.conflict != tc.wantConflict {
+				t.Errorf("want conflict %q, got: %q", tc.wantConflict, impl.conflict)
+			}
+			if reason := getLinkCondition(impl).Reason; reason != tc.wantReason {
+				t.Errorf("want reason %s, got: %s", tc.wantReason, reason)
+			}
+		})
+	}
+}
+
+func TestSetCrossHostRevision(t *testing.T) {
+	nads := []*nadv1.NetworkAttachmentDefinition{{
+		Spec: nadv1.NetworkAttachmentDefinitionSpec{
+			Config: `{"cniVersion":"0.3.1","plugins":[{"interfaceName":"eth1","remote":"172.18.0.2","type":"vxlan","vni":1}]}`,
+		},
+	}}
+	cases := map[string]struct {
+		host string
+		want map[string]string
+	}{
+		"Pinned": {
+			host: "host1",
+			want: map[string]string{corev1.LabelHostname: "host1"},
+		},
+		"NoHost": {},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pod := getTestPod(&invv1alpha1.Node{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1"}})
+			if err := setCrossHostRevision(podWorkload{}, pod, nads, tc.host); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if pod.GetAnnotations()[invv1alpha1.RevisionHash] == testHash {
+				t.Errorf("want revision hash to include the nads, got: %s", testHash)
+			}
+			if diff := cmp.Diff(tc.want, pod.Spec.NodeSelector); diff != "" {
+				t.Errorf("node selector -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// getPersistentVolumeClaimStatus returns true when all persistent volume claims are bound.
+// Claims of a storage class that waits for the first consumer are only bound once the pod
+// is scheduled, as such these claims are not waited for.
+func (r *reconciler) getPersistentVolumeClaimStatus(ctx context.Context, pvcs []*corev1.PersistentVolumeClaim) (string, bool, error) {
+	for _, newPvc := range pvcs {
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: newPvc.GetNamespace(), Name: newPvc.GetName()}, pvc); err != nil {
+			return "", false, err
+		}
+		if pvc.Status.Phase == corev1.ClaimBound {
+			continue
+		}
+		if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != "" {
+			sc := &storagev1.StorageClass{}
+			if err := r.Get(ctx, types.NamespacedName{Name: *pvc.Spec.StorageClassName}, sc); err != nil {
+				return "", false, err
+			}
+			if sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+				continue
+			}
+		}
+		return fmt.Sprintf("pvc %s not bound, phase: %s", pvc.GetName(), pvc.Status.Phase), false, nil
+	}
+	return "", true, nil
+}
@@ -21,11 +21,13 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"time"
 
-	"github.com/go-logr/logr"
+	nodev1alpha1 "github.com/henderiw-nephio/network-node-operator/apis/node/v1alpha1"
 	"github.com/henderiw-nephio/network-node-operator/controllers"
 	"github.com/henderiw-nephio/network-node-operator/controllers/ctrlconfig"
+	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
 	"github.com/henderiw-nephio/network-node-operator/pkg/node"
 	"github.com/henderiw-nephio/network/pkg/resources"
 	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
@@ -37,6 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -48,10 +51,14 @@ func init() {
 }
 
 const (
-	finalizer = "nodedeployer.nephio.com/finalizer"
+	finalizer   = "nodedeployer.nephio.com/finalizer"
+	defaultPoll = 5 * time.Second
 	// errors
 	errGetCr        = "cannot get cr"
 	errUpdateStatus = "cannot update status"
+	errListLinks    = "cannot list links"
+	// messages
+	msgTerminatingPod = "terminating old revision"
 )
 
 // SetupWithManager sets up the controller with the Manager.
@@ -67,16 +74,43 @@ func (r *reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, c i
 	if err := nadv1.AddToScheme(mgr.GetScheme()); err != nil {
 		return nil, err
 	}
+	if err := nodev1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
+		return nil, err
+	}
+
+	if err := setupIndexes(ctx, mgr); err != nil {
+		return nil, err
+	}
 
 	r.Client = mgr.GetClient()
 	r.finalizer = resource.NewAPIFinalizer(mgr.GetClient(), finalizer)
 	r.scheme = mgr.GetScheme()
+	r.recorder = mgr.GetEventRecorderFor("nodedeployer")
+	r.poll = cfg.Poll
+	if r.poll == 0 {
+		r.poll = defaultPoll
+	}
+	// the initial config sessions are bounded per provider, independent of the
+	// number of concurrent reconciles
+	r.initialConfigLimiter = newProviderLimiter(cfg.MaxConcurrentInitialConfigs)
 	r.nodeRegistry = cfg.Noderegistry
 
-	return nil, ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named("NodeDeployerController").
-		For(&invv1alpha1.Node{}).
-		Owns(&corev1.Pod{}).
+		WithOptions(cfg.Copts).
+		For(&invv1alpha1.Node{})
+	// the kinds of the workloads are selected by the types of the registered providers
+	for _, o := range getOwnedWorkloads(ctx, r.nodeRegistry, r.Client, r.scheme, mgr.GetRESTMapper()) {
+		b = b.Owns(o)
+	}
+	return nil, b.
+		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&corev1.ConfigMap{}).
+		Watches(&invv1alpha1.Link{}, &linkEventHandler{client: mgr.GetClient()}).
+		Watches(&invv1alpha1.NodeConfig{}, &nodeConfigEventHandler{client: mgr.GetClient()}).
+		Watches(&corev1.ConfigMap{}, &configMapEventHandler{client: mgr.GetClient()}).
+		Watches(&corev1.Secret{}, &secretEventHandler{client: mgr.GetClient()}).
+		Watches(&nodev1alpha1.NodeProvider{}, &nodeProviderEventHandler{client: mgr.GetClient()}).
 		Complete(r)
 }
 
@@ -86,19 +120,21 @@ type reconciler struct {
 	scheme       *runtime.Scheme
 	finalizer    *resource.APIFinalizer
 	nodeRegistry node.NodeRegistry
+	recorder     record.EventRecorder
+	poll         time.Duration
 
-	l logr.Logger
+	initialConfigLimiter *providerLimiter
 }
 
 func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	r.l = log.FromContext(ctx)
-	r.l.Info("reconcile", "req", req)
+	l := log.FromContext(ctx)
+	l.Info("reconcile", "req", req)
 
 	cr := &invv1alpha1.Node{}
 	if err := r.Get(ctx, req.NamespacedName, cr); err != nil {
 		// if the resource no longer exists the reconcile loop is done
 		if resource.IgnoreNotFound(err) != nil {
-			r.l.Error(err, errGetCr)
+			l.Error(err, errGetCr)
 			return ctrl.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetCr)
 		}
 		return ctrl.Result{}, nil
@@ -106,28 +142,63 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	cr = cr.DeepCopy()
 
 	if resource.WasDeleted(cr) {
+		msg, done, err := r.handleDelete(ctx, cr)
+		if err != nil {
+			l.Error(err, "cannot delete node resources")
+			cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+			return ctrl.Result{Requeue: true}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+		}
+		if !done {
+			if err := r.setStatus(ctx, cr, node.PhaseDeleting, nil, nil, nil); err != nil {
+				l.Error(err, "cannot set status")
+			}
+			cr.SetConditions(deleting(msg))
+			return ctrl.Result{Requeue: true, RequeueAfter: r.poll}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+		}
 		if err := r.finalizer.RemoveFinalizer(ctx, cr); err != nil {
-			r.l.Error(err, "cannot remove finalizer")
+			l.Error(err, "cannot remove finalizer")
 			cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
 			return ctrl.Result{Requeue: true}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
 		}
-		r.l.Info("Successfully deleted resource")
+		l.Info("Successfully deleted resource")
 		return ctrl.Result{}, nil
 	}
 
-	node, err := r.nodeRegistry.NewNodeOfProvider(cr.Spec.Provider, r.Client, r.scheme)
+	// add finalizer to avoid deleting the node before it is torn down
+	if err := r.finalizer.AddFinalizer(ctx, cr); err != nil {
+		l.Error(err, "cannot add finalizer")
+		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+		return ctrl.Result{Requeue: true}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+
+	n, err := r.nodeRegistry.NewNodeOfProvider(cr.Spec.Provider, r.Client, r.scheme)
 	if err != nil {
+		// the provider can be registered later by a node provider
+		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+		return ctrl.Result{RequeueAfter: r.poll}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+
+	nc, err := n.GetNodeConfig(ctx, cr)
+	if err != nil {
+		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+	// record the node config layers that are applied to the node
+	if err := r.patchAnnotations(ctx, cr, map[string]string{
+		node.NodeConfigSourcesKey: nc.GetAnnotations()[node.NodeConfigSourcesKey],
+	}); err != nil {
 		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
 		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
 	}
 
-	nc, err := node.GetNodeConfig(ctx, cr)
+	nads, err := n.GetNetworkAttachmentDefinitions(ctx, cr, nc)
 	if err != nil {
 		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
 		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
 	}
 
-	nads, err := node.GetNetworkAttachmentDefinitions(ctx, cr, nc)
+	// only the interfaces that are connected through a link get a nad
+	nads, crossHost, err := r.getLinkedNetworkAttachmentDefinitions(ctx, cr, nads)
 	if err != nil {
 		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
 		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
@@ -144,9 +215,16 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	)
 
 	if os.Getenv("ENABLE_NAD") == "true" {
+		newNads := []client.Object{}
 		for _, nad := range nads {
-			r.l.Info("nad info", "name", nad.GetName())
+			l.Info("nad info", "name", nad.GetName())
 			res.AddNewResource(nad)
+			newNads = append(newNads, nad)
+		}
+		// delete the nads of the links that are removed
+		if err := r.deleteUnusedResources(ctx, cr, &nadv1.NetworkAttachmentDefinitionList{}, newNads); err != nil {
+			cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+			return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
 		}
 	}
 
@@ -155,85 +233,276 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
 	}
 
-	newPod, err := node.GetPodSpec(ctx, cr, nc, nads)
+	pvcs, err := n.GetPersistentVolumeClaims(ctx, cr, nc)
 	if err != nil {
 		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
 		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
 	}
-	if err := r.handlePodUpdate(ctx, cr, newPod); err != nil {
+
+	pvcRes := resources.New(
+		resource.NewAPIPatchingApplicator(r.Client),
+		resources.Config{
+			CR: cr,
+			Owns: []schema.GroupVersionKind{
+				corev1.SchemeGroupVersion.WithKind(reflect.TypeOf(corev1.PersistentVolumeClaim{}).Name()),
+			},
+		},
+	)
+	// the pvcs of the persistent volumes that are removed from the node config are
+	// kept with their data, the pvcs are only deleted when the node is deleted
+	for _, pvc := range pvcs {
+		pvcRes.AddNewResource(pvc)
+	}
+	if err := pvcRes.APIApply(ctx); err != nil {
+		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+
+	startupConfig, err := n.GetStartupConfig(ctx, cr, nc)
+	if err != nil {
 		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
 		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
 	}
 
-	// at this stage the pod should exist
-	pod := &corev1.Pod{}
-	if err := r.Get(ctx, req.NamespacedName, pod); err != nil {
+	cmRes := resources.New(
+		resource.NewAPIPatchingApplicator(r.Client),
+		resources.Config{
+			CR: cr,
+			Owns: []schema.GroupVersionKind{
+				corev1.SchemeGroupVersion.WithKind(reflect.TypeOf(corev1.ConfigMap{}).Name()),
+			},
+		},
+	)
+	newCms := []client.Object{}
+	if startupConfig != nil {
+		cmRes.AddNewResource(startupConfig)
+		newCms = append(newCms, startupConfig)
+	}
+	// delete the startup config when it is removed from the node config
+	if err := r.deleteUnusedResources(ctx, cr, &corev1.ConfigMapList{}, newCms); err != nil {
+		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+	if err := cmRes.APIApply(ctx); err != nil {
 		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
 		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
 	}
 
-	podIPs, msg, ready := getPodStatus(pod)
+	// the workload is only created when the persistent volume claims are bound
+	msg, bound, err := r.getPersistentVolumeClaimStatus(ctx, pvcs)
+	if err != nil {
+		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+	if !bound {
+		if err := r.setStatus(ctx, cr, node.PhasePending, nil, nil, nil); err != nil {
+			l.Error(err, "cannot set status")
+		}
+		cr.SetConditions(resourcev1alpha1.NotReady(msg))
+		return ctrl.Result{Requeue: true, RequeueAfter: r.poll}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+
+	w := getWorkload(n.GetProviderType(ctx))
+	newObj, err := w.getObject(ctx, n, cr, nc, nads)
+	if err != nil {
+		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+	if crossHost && os.Getenv("ENABLE_NAD") == "true" {
+		if err := setCrossHostRevision(w, newObj, nads, cr.GetAnnotations()[node.HostKey]); err != nil {
+			cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+			return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+		}
+	}
+	msg, done, err := r.handleWorkloadUpdate(ctx, cr, w, newObj)
+	if err != nil {
+		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+	if !done {
+		if err := r.setStatus(ctx, cr, node.PhasePending, nil, nil, nil); err != nil {
+			l.Error(err, "cannot set status")
+		}
+		cr.SetConditions(resourcev1alpha1.NotReady(msg))
+		return ctrl.Result{Requeue: true, RequeueAfter: r.poll}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+
+	// at this stage the workload should exist
+	obj := w.newObject()
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+
+	podIPs, phase, msg, ready := w.getStatus(obj)
 	if !ready {
+		// a node that was ready before is degraded
+		if cr.GetAnnotations()[node.PhaseKey] == string(node.PhaseReady) {
+			phase = node.PhaseDegraded
+		}
+		if err := r.setStatus(ctx, cr, phase, w, obj, nil); err != nil {
+			l.Error(err, "cannot set status")
+		}
 		cr.SetConditions(resourcev1alpha1.NotReady(msg))
-		return ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+		return ctrl.Result{Requeue: true, RequeueAfter: r.poll}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+
+	l.Info("workload ips", "kind", w.kind(), "ips", podIPs)
+	// only network nodes require an initial config
+	if providerType := n.GetProviderType(ctx); providerType == node.ProviderTypeNetwork || providerType == node.ProviderTypeVM {
+		if err := r.handleInitialConfig(ctx, cr, n, w, obj, podIPs); err != nil {
+			l.Error(err, "cannot set initial config")
+			if err := r.setStatus(ctx, cr, node.PhaseDegraded, w, obj, nil); err != nil {
+				l.Error(err, "cannot set status")
+			}
+			cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
+			return ctrl.Result{Requeue: true}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+		}
 	}
 
-	r.l.Info("pod ips", "ips", podIPs)
-	if err := node.SetInitialConfig(ctx, cr, podIPs); err != nil {
-		r.l.Error(err, "cannot set initial config")
+	if err := r.setStatus(ctx, cr, node.PhaseReady, w, obj, nil); err != nil {
 		cr.SetConditions(resourcev1alpha1.Failed(err.Error()))
-		return ctrl.Result{Requeue: true}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
 	}
 
-	r.l.Info("ready", "req", req)
+	l.Info("ready", "req", req)
 	cr.SetConditions(resourcev1alpha1.Ready())
 	return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
 }
 
-func (r *reconciler) handlePodUpdate(ctx context.Context, cr *invv1alpha1.Node, newPod *corev1.Pod) error {
-	var create bool
-	existingPod := &corev1.Pod{}
+// handleWorkloadUpdate replaces the workload when the revision hash of the workload
+// changed. Since pods are immutable the old revision is deleted and the new revision
+// is only created when the old workload is gone, to avoid conflicts with the
+// terminating workload of the same name. The returned message reports the step in
+// progress, done is true when the workload of the new revision exists.
+func (r *reconciler) handleWorkloadUpdate(ctx context.Context, cr *invv1alpha1.Node, w workload, newObj client.Object) (string, bool, error) {
+	l := log.FromContext(ctx)
+	existingObj := w.newObject()
 	if err := r.Get(ctx, types.NamespacedName{
 		Name:      cr.GetName(),
 		Namespace: cr.GetNamespace(),
-	}, existingPod); err != nil {
+	}, existingObj); err != nil {
 		if resource.IgnoreNotFound(err) != nil {
-			return err
-		}
-		// pod does not exist -> indicate to create it
-		create = true
-	} else {
-		r.l.Info("pod exists",
-			"oldHash", existingPod.GetAnnotations()[invv1alpha1.RevisionHash],
-			"newHash", newPod.GetAnnotations()[invv1alpha1.RevisionHash],
-		)
-		if newPod.GetAnnotations()[invv1alpha1.RevisionHash] != existingPod.GetAnnotations()[invv1alpha1.RevisionHash] {
-			// pod spec changed, since pods are immutable we delete and create the pod
-			r.l.Info("pod spec changed")
-			if err := r.Delete(ctx, existingPod); err != nil {
-				return err
-			}
-			create = true
+			return "", false, err
+		}
+		// workload does not exist -> create it
+		if err := r.Create(ctx, newObj); err != nil {
+			return "", false, err
 		}
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, w.kind()+"Created", "created %s %s revision %s", strings.ToLower(w.kind()), newObj.GetName(), newObj.GetAnnotations()[invv1alpha1.RevisionHash])
+		return "", true, nil
 	}
 
-	if create {
-		if err := r.Create(ctx, newPod); err != nil {
-			return err
+	if existingObj.GetDeletionTimestamp() != nil {
+		l.Info("workload terminating", "kind", w.kind(), "name", existingObj.GetName())
+		return msgTerminatingPod, false, nil
+	}
+
+	l.Info("workload exists",
+		"kind", w.kind(),
+		"oldHash", existingObj.GetAnnotations()[invv1alpha1.RevisionHash],
+		"newHash", newObj.GetAnnotations()[invv1alpha1.RevisionHash],
+	)
+	if newObj.GetAnnotations()[invv1alpha1.RevisionHash] != existingObj.GetAnnotations()[invv1alpha1.RevisionHash] {
+		// workload spec changed, since pods are immutable we delete the workload and
+		// create the new revision once the old revision is terminated
+		l.Info("workload spec changed", "kind", w.kind())
+		if err := r.Delete(ctx, existingObj); resource.IgnoreNotFound(err) != nil {
+			return "", false, err
 		}
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, w.kind()+"Deleted", "deleted %s %s revision %s", strings.ToLower(w.kind()), existingObj.GetName(), existingObj.GetAnnotations()[invv1alpha1.RevisionHash])
+		return msgTerminatingPod, false, nil
 	}
-	return nil
+	return "", true, nil
 }
 
-func getPodStatus(pod *corev1.Pod) ([]corev1.PodIP, string, bool) {
-	if len(pod.Status.ContainerStatuses) == 0 {
-		return nil, "pod conditions empty", false
+// handleInitialConfig applies the initial config to the node when the hash of the
+// initial config differs from the hash recorded on the workload. Since the hash is
+// recorded on the workload, a recreated workload is bootstrapped again.
+func (r *reconciler) handleInitialConfig(ctx context.Context, cr *invv1alpha1.Node, n node.Node, w workload, obj client.Object, ips []corev1.PodIP) error {
+	l := log.FromContext(ctx)
+	hash, err := n.GetInitialConfigHash(ctx, cr)
+	if err != nil {
+		return err
+	}
+	if obj.GetAnnotations()[node.InitialConfigHashKey] == hash {
+		l.Info("initial config unchanged", "hash", hash)
+		return nil
+	}
+	if err := r.setStatus(ctx, cr, node.PhaseBootstrapping, w, obj, nil); err != nil {
+		return err
+	}
+	if err := r.initialConfigLimiter.acquire(ctx, cr.Spec.Provider); err != nil {
+		return err
+	}
+	defer r.initialConfigLimiter.release(cr.Spec.Provider)
+	if err := n.SetInitialConfig(ctx, cr, ips); err != nil {
+		return err
+	}
+	// failing to read the device information does not fail the bootstrap
+	info, err := n.GetDeviceInfo(ctx, cr, ips)
+	if err != nil {
+		l.Error(err, "cannot get device info")
+	}
+	if err := r.setStatus(ctx, cr, node.PhaseBootstrapping, w, obj, info); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
 	}
-	if !pod.Status.ContainerStatuses[0].Ready {
-		return nil, "pod not ready empty", false
+	annotations[node.InitialConfigHashKey] = hash
+	obj.SetAnnotations(annotations)
+	return r.Patch(ctx, obj, patch)
+}
+
+// getLinkedNetworkAttachmentDefinitions returns the nads of the interfaces that are
+// referenced by an endpoint of a link in the namespace of the node and the nads that
+// use another cni plugin than wire, e.g. macvlan or sriov. The wire plugin of
+// the nad of a link between endpoints on different hosts is replaced by a vxlan plugin,
+// crossHost is true when the node has such a link. The implementation of the links is
+// recorded in the status of the links.
+func (r *reconciler) getLinkedNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nads []*nadv1.NetworkAttachmentDefinition) ([]*nadv1.NetworkAttachmentDefinition, bool, error) {
+	linkedItfces, err := node.GetLinks(ctx, r.Client, cr)
+	if err != nil {
+		return nil, false, errors.Wrap(err, errListLinks)
 	}
-	if len(pod.Status.PodIPs) == 0 {
-		return nil, "no ip provided", false
+
+	crossHost := false
+	linkedNads := []*nadv1.NetworkAttachmentDefinition{}
+	for _, n := range nads {
+		// the interfaces that the node config connects through another cni plugin
+		// than wire do not need a link
+		wire, err := isWireNetworkAttachmentDefinition(n)
+		if err != nil {
+			return nil, false, err
+		}
+		if !wire {
+			linkedNads = append(linkedNads, n)
+			continue
+		}
+		itfceName := n.GetLabels()[invv1alpha1.NephioInterfaceNameKey]
+		link, ok := linkedItfces[itfceName]
+		if !ok {
+			continue
+		}
+		impl, err := r.getLinkImplementation(ctx, cr, itfceName, link)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := r.setLinkStatus(ctx, link, impl); err != nil {
+			return nil, false, err
+		}
+		if impl.cniType == nad.VxlanPluginType {
+			n, err = getVxlanNetworkAttachmentDefinition(n, impl)
+			if err != nil {
+				return nil, false, err
+			}
+			crossHost = true
+		}
+		linkedNads = append(linkedNads, n)
 	}
-	return pod.Status.PodIPs, "", true
+	return linkedNads, crossHost, nil
 }
@@ -0,0 +1,474 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/nephio-project/nephio/controllers/pkg/resource"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	resourcev1alpha1 "github.com/nokia/k8s-ipam/apis/resource/common/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testProvider = "test.nephio.org"
+	testHash     = "test"
+)
+
+// testNode is a node provider that records the concurrent initial config sessions
+type testNode struct {
+	client.Client
+	scheme *runtime.Scheme
+
+	active    *int32
+	maxActive *int32
+	calls     *int32
+}
+
+func (r *testNode) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*corev1.Pod, error) {
+	pod := getTestPod(cr)
+	if err := ctrl.SetControllerReference(cr, pod, r.scheme); err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+func (r *testNode) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	return nil, nil
+}
+
+func (r *testNode) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
+	return nil, nil
+}
+
+func (r *testNode) GetStartupConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) (*corev1.ConfigMap, error) {
+	return nil, nil
+}
+
+func (r *testNode) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	atomic.AddInt32(r.calls, 1)
+	active := atomic.AddInt32(r.active, 1)
+	defer atomic.AddInt32(r.active, -1)
+	for {
+		max := atomic.LoadInt32(r.maxActive)
+		if active <= max || atomic.CompareAndSwapInt32(r.maxActive, max, active) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return nil
+}
+
+func (r *testNode) GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error) {
+	return testHash, nil
+}
+
+func (r *testNode) GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error) {
+	return &node.DeviceInfo{SoftwareVersion: "v1.0.0", Chassis: "test"}, nil
+}
+
+func (r *testNode) Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	return nil
+}
+
+func (r *testNode) GetNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
+	return &invv1alpha1.NodeConfig{}, nil
+}
+
+func (r *testNode) GetNodeModelConfig(ctx context.Context, nc *invv1alpha1.NodeConfig) *corev1.ObjectReference {
+	return nil
+}
+
+func (r *testNode) GetNodeModel(ctx context.Context, nc *invv1alpha1.NodeConfig) (*invv1alpha1.NodeModel, error) {
+	return &invv1alpha1.NodeModel{}, nil
+}
+
+func (r *testNode) GetProviderType(ctx context.Context) node.ProviderType {
+	return node.ProviderTypeNetwork
+}
+
+func (r *testNode) GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec {
+	return &invv1alpha1.NodeConfigSpec{Provider: testProvider}
+}
+
+func (r *testNode) ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error {
+	return nil
+}
+
+func getTestPod(cr *invv1alpha1.Node) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cr.GetNamespace(),
+			Name:      cr.GetName(),
+			Annotations: map[string]string{
+				invv1alpha1.RevisionHash: testHash,
+			},
+		},
+	}
+}
+
+func TestReconcileConcurrent(t *testing.T) {
+	cases := map[string]struct {
+		nodes                       int
+		maxConcurrentInitialConfigs int
+	}{
+		"Bounded": {
+			nodes:                       20,
+			maxConcurrentInitialConfigs: 2,
+		},
+		"Single": {
+			nodes:                       5,
+			maxConcurrentInitialConfigs: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			if err := invv1alpha1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			if err := nadv1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+
+			objs := []client.Object{}
+			for i := 0; i < tc.nodes; i++ {
+				cr := &invv1alpha1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      fmt.Sprintf("node%d", i),
+						UID:       types.UID(fmt.Sprintf("uid%d", i)),
+					},
+					Spec: invv1alpha1.NodeSpec{
+						Provider: testProvider,
+					},
+				}
+				pod := getTestPod(cr)
+				pod.Status = corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+					},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+					},
+					PodIPs: []corev1.PodIP{{IP: fmt.Sprintf("10.0.0.%d", i+1)}},
+				}
+				objs = append(objs, cr, pod)
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(s).
+				WithObjects(objs...).
+				WithStatusSubresource(&invv1alpha1.Node{}).
+				Build()
+
+			var active, maxActive, calls int32
+			nr := node.NewNodeRegistry()
+			nr.Register(testProvider, func(c client.Client, s *runtime.Scheme) node.Node {
+				return &testNode{Client: c, scheme: s, active: &active, maxActive: &maxActive, calls: &calls}
+			})
+
+			r := &reconciler{
+				Client:               c,
+				scheme:               s,
+				finalizer:            resource.NewAPIFinalizer(c, finalizer),
+				nodeRegistry:         nr,
+				recorder:             record.NewFakeRecorder(100),
+				poll:                 defaultPoll,
+				initialConfigLimiter: newProviderLimiter(tc.maxConcurrentInitialConfigs),
+			}
+
+			var wg sync.WaitGroup
+			for i := 0; i < tc.nodes; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("node%d", i)}}
+					// the second reconcile skips the initial config since the hash is recorded on the pod
+					for j := 0; j < 2; j++ {
+						if _, err := r.Reconcile(context.Background(), req); err != nil {
+							t.Errorf("reconcile %s: unexpected error: %s", req.Name, err)
+						}
+					}
+				}(i)
+			}
+			wg.Wait()
+
+			if maxActive > int32(tc.maxConcurrentInitialConfigs) {
+				t.Errorf("want at most %d concurrent initial configs, got: %d", tc.maxConcurrentInitialConfigs, maxActive)
+			}
+			if calls != int32(tc.nodes) {
+				t.Errorf("want %d initial configs, got: %d", tc.nodes, calls)
+			}
+			for i := 0; i < tc.nodes; i++ {
+				cr := &invv1alpha1.Node{}
+				if err := c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: fmt.Sprintf("node%d", i)}, cr); err != nil {
+					t.Fatal(err)
+				}
+				if got := cr.GetAnnotations()[node.PhaseKey]; got != string(node.PhaseReady) {
+					t.Errorf("node%d: want phase %s, got: %s", i, node.PhaseReady, got)
+				}
+				if got := cr.GetCondition(resourcev1alpha1.ConditionTypeReady).Status; got != metav1.ConditionTrue {
+					t.Errorf("node%d: want ready condition %s, got: %s", i, metav1.ConditionTrue, got)
+				}
+			}
+		})
+	}
+}
+
+// testVMNode is a node provider of the vm provider type
+type testVMNode struct {
+	testNode
+}
+
+func (r *testVMNode) GetProviderType(ctx context.Context) node.ProviderType {
+	return node.ProviderTypeVM
+}
+
+func (r *testVMNode) GetVirtualMachineInstanceSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*unstructured.Unstructured, error) {
+	vmi := getTestVMI(cr)
+	if err := ctrl.SetControllerReference(cr, vmi, r.scheme); err != nil {
+		return nil, err
+	}
+	return vmi, nil
+}
+
+func getTestVMI(cr *invv1alpha1.Node) *unstructured.Unstructured {
+	vmi := node.NewVirtualMachineInstance()
+	vmi.SetNamespace(cr.GetNamespace())
+	vmi.SetName(cr.GetName())
+	vmi.SetAnnotations(map[string]string{
+		invv1alpha1.RevisionHash: testHash,
+	})
+	return vmi
+}
+
+func TestReconcileVirtualMachineInstance(t *testing.T) {
+	cases := map[string]struct {
+		status    map[string]any
+		wantPhase node.Phase
+		wantReady bool
+		wantIPs   string
+		wantCalls int32
+	}{
+		"NotCreated": {
+			wantPhase: node.PhasePending,
+		},
+		"Scheduled": {
+			status: map[string]any{
+				"phase": "Scheduled",
+			},
+			wantPhase: node.PhasePodScheduled,
+		},
+		"NotReady": {
+			status: map[string]any{
+				"phase": "Running",
+			},
+			wantPhase: node.PhaseBooting,
+		},
+		"Ready": {
+			status: map[string]any{
+				"phase": "Running",
+				"conditions": []any{
+					map[string]any{"type": "Ready", "status": "True"},
+				},
+				"interfaces": []any{
+					map[string]any{"name": "default", "ipAddresses": []any{"10.0.0.1", "fd00::1"}},
+					map[string]any{"name": "node1-e1-1"},
+				},
+			},
+			wantPhase: node.PhaseReady,
+			wantReady: true,
+			wantIPs:   "10.0.0.1,fd00::1",
+			wantCalls: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			if err := invv1alpha1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			if err := nadv1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+
+			cr := &invv1alpha1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "node1",
+					UID:       types.UID("uid1"),
+				},
+				Spec: invv1alpha1.NodeSpec{
+					Provider: testProvider,
+				},
+			}
+			objs := []client.Object{cr}
+			if tc.status != nil {
+				vmi := getTestVMI(cr)
+				vmi.Object["status"] = tc.status
+				objs = append(objs, vmi)
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(s).
+				WithObjects(objs...).
+				WithStatusSubresource(&invv1alpha1.Node{}).
+				Build()
+
+			var active, maxActive, calls int32
+			nr := node.NewNodeRegistry()
+			nr.Register(testProvider, func(c client.Client, s *runtime.Scheme) node.Node {
+				return &testVMNode{testNode{Client: c, scheme: s, active: &active, maxActive: &maxActive, calls: &calls}}
+			})
+
+			r := &reconciler{
+				Client:               c,
+				scheme:               s,
+				finalizer:            resource.NewAPIFinalizer(c, finalizer),
+				nodeRegistry:         nr,
+				recorder:             record.NewFakeRecorder(100),
+				poll:                 defaultPoll,
+				initialConfigLimiter: newProviderLimiter(1),
+			}
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "node1"}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("reconcile: unexpected error: %s", err)
+			}
+
+			// the workload of the node is a virtual machine instance, not a pod
+			if err := c.Get(context.Background(), req.NamespacedName, node.NewVirtualMachineInstance()); err != nil {
+				t.Errorf("want virtual machine instance, got: %s", err)
+			}
+			if err := c.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); resource.IgnoreNotFound(err) != nil || err == nil {
+				t.Errorf("want no pod, got: %v", err)
+			}
+
+			got := &invv1alpha1.Node{}
+			if err := c.Get(context.Background(), req.NamespacedName, got); err != nil {
+				t.Fatal(err)
+			}
+			if phase := got.GetAnnotations()[node.PhaseKey]; phase != string(tc.wantPhase) {
+				t.Errorf("want phase %s, got: %s", tc.wantPhase, phase)
+			}
+			if ready := got.GetCondition(resourcev1alpha1.ConditionTypeReady).Status == metav1.ConditionTrue; ready != tc.wantReady {
+				t.Errorf("want ready %t, got: %t", tc.wantReady, ready)
+			}
+			if ips := got.GetAnnotations()[node.PodIPsKey]; ips != tc.wantIPs {
+				t.Errorf("want ips %q, got: %q", tc.wantIPs, ips)
+			}
+			if calls != tc.wantCalls {
+				t.Errorf("want %d initial configs, got: %d", tc.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestGetOwnedWorkloads(t *testing.T) {
+	cases := map[string]struct {
+		providers map[string]node.ProviderType
+		// notServed is true when the cluster does not serve the kubevirt crds
+		notServed bool
+		wantKinds []string
+	}{
+		"None": {
+			wantKinds: []string{"Pod"},
+		},
+		"Pods": {
+			providers: map[string]node.ProviderType{
+				"network.example.com": node.ProviderTypeNetwork,
+				"server.example.com":  node.ProviderTypeServer,
+			},
+			wantKinds: []string{"Pod"},
+		},
+		"VirtualMachineInstances": {
+			providers: map[string]node.ProviderType{
+				"network.example.com": node.ProviderTypeNetwork,
+				"vm1.example.com":     node.ProviderTypeVM,
+				"vm2.example.com":     node.ProviderTypeVM,
+			},
+			wantKinds: []string{"Pod", "VirtualMachineInstance"},
+		},
+		"VirtualMachineInstancesNotServed": {
+			providers: map[string]node.ProviderType{
+				"network.example.com": node.ProviderTypeNetwork,
+				"vm1.example.com":     node.ProviderTypeVM,
+			},
+			notServed: true,
+			wantKinds: []string{"Pod"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nr := node.NewNodeRegistry()
+			for provider, providerType := range tc.providers {
+				providerType := providerType
+				nr.Register(provider, func(c client.Client, s *runtime.Scheme) node.Node {
+					if providerType == node.ProviderTypeVM {
+						return &testVMNode{}
+					}
+					return &testNode{}
+				})
+			}
+
+			mapper := meta.NewDefaultRESTMapper(nil)
+			if !tc.notServed {
+				mapper.Add(node.VirtualMachineInstanceGroupVersionKind, meta.RESTScopeNamespace)
+			}
+
+			gotKinds := []string{}
+			for _, o := range getOwnedWorkloads(context.Background(), nr, nil, nil, mapper) {
+				switch o := o.(type) {
+				case *corev1.Pod:
+					gotKinds = append(gotKinds, "Pod")
+				case *unstructured.Unstructured:
+					gotKinds = append(gotKinds, o.GetKind())
+				}
+			}
+			if diff := cmp.Diff(tc.wantKinds, gotKinds); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
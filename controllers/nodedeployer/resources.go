@@ -0,0 +1,67 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/nephio-project/nephio/controllers/pkg/resource"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// deleteUnusedResources deletes the resources of the typed list that are controlled by
+// the node and that are not part of the new resources. The existing resources of the
+// resources library cannot be used for this since they are keyed differently than the
+// new resources, which would delete and recreate the resources on every reconcile. The
+// list is typed such that it is served by the informer cache of the manager.
+func (r *reconciler) deleteUnusedResources(ctx context.Context, cr *invv1alpha1.Node, objs client.ObjectList, newObjs []client.Object) error {
+	l := log.FromContext(ctx)
+	newNames := map[string]struct{}{}
+	for _, o := range newObjs {
+		newNames[o.GetName()] = struct{}{}
+	}
+
+	opts := []client.ListOption{
+		client.InNamespace(cr.GetNamespace()),
+		client.MatchingLabels{
+			invv1alpha1.NephioNodeNameKey: cr.GetName(),
+		},
+	}
+	if err := r.List(ctx, objs, opts...); err != nil {
+		return err
+	}
+	return meta.EachListItem(objs, func(obj runtime.Object) error {
+		o, ok := obj.(client.Object)
+		if !ok {
+			return nil
+		}
+		if _, ok := newNames[o.GetName()]; ok || !metav1.IsControlledBy(o, cr) {
+			return nil
+		}
+		l.Info("delete unused resource", "kind", reflect.TypeOf(o).Elem().Name(), "name", o.GetName())
+		if err := r.Delete(ctx, o); resource.IgnoreNotFound(err) != nil {
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/srlinux"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/nephio-project/nephio/controllers/pkg/resource"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	resourcev1alpha1 "github.com/nokia/k8s-ipam/apis/resource/common/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// testNadNode is a node provider with a nad for every interface
+type testNadNode struct {
+	testNode
+}
+
+func (r *testNadNode) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	return node.GetWireNetworkAttachmentDefinitions(cr, []node.Interface{
+		{Name: "e1-1", ContainerName: "eth1"},
+		{Name: "e1-2", ContainerName: "eth2"},
+	}, r.scheme)
+}
+
+func TestReconcileNetworkAttachmentDefinitions(t *testing.T) {
+	t.Setenv("ENABLE_NAD", "true")
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := invv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := nadv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cr := &invv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "node1",
+			UID:       types.UID("uid1"),
+		},
+		Spec: invv1alpha1.NodeSpec{
+			Provider: testProvider,
+		},
+	}
+	// only e1-1 is linked
+	link := &invv1alpha1.Link{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1-node2"},
+		Spec: invv1alpha1.LinkSpec{
+			Endpoints: []invv1alpha1.LinkEndpoint{
+				{NodeName: "node1", InterfaceName: "e1-1"},
+				{NodeName: "node2", InterfaceName: "e1-1"},
+			},
+		},
+	}
+	// the nad of the removed link of e1-3 is stale
+	stale, err := node.GetWireNetworkAttachmentDefinitions(cr, []node.Interface{{Name: "e1-3", ContainerName: "eth3"}}, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	creates := []string{}
+	deletes := []string{}
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(cr, link, stale[0]).
+		WithStatusSubresource(&invv1alpha1.Node{}, &invv1alpha1.Link{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if strings.HasPrefix(obj.GetName(), "node1-") {
+					creates = append(creates, obj.GetName())
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if strings.HasPrefix(obj.GetName(), "node1-") {
+					deletes = append(deletes, obj.GetName())
+				}
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	var active, maxActive, calls int32
+	nr := node.NewNodeRegistry()
+	nr.Register(testProvider, func(c client.Client, s *runtime.Scheme) node.Node {
+		return &testNadNode{testNode{Client: c, scheme: s, active: &active, maxActive: &maxActive, calls: &calls}}
+	})
+
+	r := &reconciler{
+		Client:               c,
+		scheme:               s,
+		finalizer:            resource.NewAPIFinalizer(c, finalizer),
+		nodeRegistry:         nr,
+		recorder:             record.NewFakeRecorder(100),
+		poll:                 defaultPoll,
+		initialConfigLimiter: newProviderLimiter(1),
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "node1"}}
+	// the second reconcile does not recreate the current nad
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("reconcile %d: unexpected error: %s", i, err)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"node1-e1-1"}, creates); diff != "" {
+		t.Errorf("creates -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"node1-e1-3"}, deletes); diff != "" {
+		t.Errorf("deletes -want, +got:\n%s", diff)
+	}
+
+	nads := &nadv1.NetworkAttachmentDefinitionList{}
+	if err := c.List(context.Background(), nads, client.InNamespace("default")); err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, n := range nads.Items {
+		names = append(names, n.GetName())
+	}
+	sort.Strings(names)
+	if diff := cmp.Diff([]string{"node1-e1-1"}, names); diff != "" {
+		t.Errorf("nads -want, +got:\n%s", diff)
+	}
+}
+
+func TestReconcileRemovedPersistentVolume(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := invv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cr := &invv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "node1",
+			UID:       types.UID("uid1"),
+		},
+		Spec: invv1alpha1.NodeSpec{
+			Provider: testProvider,
+		},
+	}
+	// the logs volume is removed from the node config, the test node has no volumes
+	pvcs, err := node.GetPersistentVolumeClaims(cr, &invv1alpha1.NodeConfig{
+		Spec: invv1alpha1.NodeConfigSpec{
+			PersistentVolumes: []invv1alpha1.PersistentVolume{{Name: "logs", MountPath: "/logs"}},
+		},
+	}, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(cr, pvcs[0]).
+		WithStatusSubresource(&invv1alpha1.Node{}).
+		Build()
+
+	var active, maxActive, calls int32
+	nr := node.NewNodeRegistry()
+	nr.Register(testProvider, func(c client.Client, s *runtime.Scheme) node.Node {
+		return &testNode{Client: c, scheme: s, active: &active, maxActive: &maxActive, calls: &calls}
+	})
+
+	r := &reconciler{
+		Client:               c,
+		scheme:               s,
+		finalizer:            resource.NewAPIFinalizer(c, finalizer),
+		nodeRegistry:         nr,
+		recorder:             record.NewFakeRecorder(100),
+		poll:                 defaultPoll,
+		initialConfigLimiter: newProviderLimiter(1),
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "node1"}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the pvc and its data are kept until the node is deleted
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(pvcs[0]), pvc); err != nil {
+		t.Errorf("want pvc %s kept, got: %s", pvcs[0].GetName(), err)
+	}
+}
+
+func TestReconcileMissingNodeModel(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "network-system")
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := invv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := nadv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cr := &invv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "node1",
+			UID:       types.UID("uid1"),
+		},
+		Spec: invv1alpha1.NodeSpec{
+			Provider: srlinux.NokiaSRLinuxProvider,
+		},
+	}
+	// the variant of the default model exists, its node model does not
+	variants := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "network-system", Name: "srlinux.nokia.com-variants"},
+		Data:       map[string]string{"ixrd3l": ""},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(cr, variants).
+		WithStatusSubresource(&invv1alpha1.Node{}).
+		Build()
+
+	nr := node.NewNodeRegistry()
+	srlinux.Register(nr)
+	r := &reconciler{
+		Client:               c,
+		scheme:               s,
+		finalizer:            resource.NewAPIFinalizer(c, finalizer),
+		nodeRegistry:         nr,
+		recorder:             record.NewFakeRecorder(100),
+		poll:                 defaultPoll,
+		initialConfigLimiter: newProviderLimiter(1),
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "node1"}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got := &invv1alpha1.Node{}
+	if err := c.Get(context.Background(), req.NamespacedName, got); err != nil {
+		t.Fatal(err)
+	}
+	cond := got.GetCondition(resourcev1alpha1.ConditionTypeReady)
+	if cond.Reason != string(resourcev1alpha1.ConditionReasonFailed) || !strings.Contains(cond.Message, "cannot get node model") {
+		t.Errorf("want failed condition for the missing node model, got: %s %s", cond.Reason, cond.Message)
+	}
+}
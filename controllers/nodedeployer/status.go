@@ -0,0 +1,114 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// setStatus records the phase, the workload with its host and ips and the device
+// information in the annotations of the node, since the status of the node api only
+// holds conditions. The annotations are the interim status until the inventory api
+// has status fields for it, see the keys in the node package. The host and the device
+// information are only updated when provided, the host is kept to place a replaced
+// workload on the same host.
+func (r *reconciler) setStatus(ctx context.Context, cr *invv1alpha1.Node, phase node.Phase, w workload, obj client.Object, info *node.DeviceInfo) error {
+	annotations := map[string]string{
+		node.PhaseKey: string(phase),
+	}
+	if obj != nil {
+		podIPs := w.getIPs(obj)
+		ips := make([]string, 0, len(podIPs))
+		for _, ip := range podIPs {
+			ips = append(ips, ip.IP)
+		}
+		annotations[node.PodNameKey] = obj.GetName()
+		annotations[node.PodUIDKey] = string(obj.GetUID())
+		annotations[node.PodIPsKey] = strings.Join(ips, ",")
+		if host := w.getHost(obj); host != "" {
+			annotations[node.HostKey] = host
+		}
+	}
+	if info != nil {
+		annotations[node.SoftwareVersionKey] = info.SoftwareVersion
+		annotations[node.ChassisKey] = info.Chassis
+	}
+
+	return r.patchAnnotations(ctx, cr, annotations)
+}
+
+// patchAnnotations patches the annotations of the node when they change, unchanged
+// annotations do not write the node. The status of the cr is left untouched such
+// that the conditions can be updated afterwards.
+func (r *reconciler) patchAnnotations(ctx context.Context, cr *invv1alpha1.Node, annotations map[string]string) error {
+	changed := false
+	for k, v := range annotations {
+		if cr.GetAnnotations()[k] != v {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	newCr := cr.DeepCopy()
+	patch := client.MergeFrom(cr.DeepCopy())
+	newAnnotations := newCr.GetAnnotations()
+	if newAnnotations == nil {
+		newAnnotations = map[string]string{}
+	}
+	for k, v := range annotations {
+		newAnnotations[k] = v
+	}
+	newCr.SetAnnotations(newAnnotations)
+	if err := r.Patch(ctx, newCr, patch); err != nil {
+		return err
+	}
+	cr.SetAnnotations(newCr.GetAnnotations())
+	cr.SetResourceVersion(newCr.GetResourceVersion())
+	return nil
+}
+
+// getPodStatus returns the ips of the pod, the phase of the node derived from the pod
+// and a message when the pod is not ready.
+func getPodStatus(pod *corev1.Pod) ([]corev1.PodIP, node.Phase, string, bool) {
+	scheduled := false
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionTrue {
+			scheduled = true
+		}
+	}
+	if !scheduled {
+		return nil, node.PhasePending, "pod not scheduled", false
+	}
+	if len(pod.Status.ContainerStatuses) == 0 || pod.Status.ContainerStatuses[0].State.Running == nil {
+		return nil, node.PhasePodScheduled, "pod containers not running", false
+	}
+	if !pod.Status.ContainerStatuses[0].Ready {
+		return nil, node.PhaseBooting, "pod not ready", false
+	}
+	if len(pod.Status.PodIPs) == 0 {
+		return nil, node.PhaseBooting, "no ip provided", false
+	}
+	return pod.Status.PodIPs, node.PhaseReady, "", true
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestSetStatus(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1", UID: "uid1"},
+		Spec:       corev1.PodSpec{NodeName: "host1"},
+		Status:     corev1.PodStatus{PodIPs: []corev1.PodIP{{IP: "10.0.0.1"}}},
+	}
+
+	cases := map[string]struct {
+		annotations map[string]string
+		phase       node.Phase
+		obj         client.Object
+		info        *node.DeviceInfo
+		wantPatches int
+		want        map[string]string
+	}{
+		"Phase": {
+			phase:       node.PhasePending,
+			wantPatches: 1,
+			want:        map[string]string{node.PhaseKey: "Pending"},
+		},
+		"Unchanged": {
+			annotations: map[string]string{
+				node.PhaseKey:   "Ready",
+				node.PodNameKey: "node1",
+				node.PodUIDKey:  "uid1",
+				node.PodIPsKey:  "10.0.0.1",
+				node.HostKey:    "host1",
+			},
+			phase: node.PhaseReady,
+			obj:   pod,
+			want: map[string]string{
+				node.PhaseKey:   "Ready",
+				node.PodNameKey: "node1",
+				node.PodUIDKey:  "uid1",
+				node.PodIPsKey:  "10.0.0.1",
+				node.HostKey:    "host1",
+			},
+		},
+		"DeviceInfo": {
+			// the annotations that are not provided are kept
+			annotations: map[string]string{
+				node.PhaseKey: "Bootstrapping",
+				node.HostKey:  "host1",
+				"other":       "value",
+			},
+			phase:       node.PhaseReady,
+			info:        &node.DeviceInfo{SoftwareVersion: "v23.3.1", Chassis: "7220 IXR-D3"},
+			wantPatches: 1,
+			want: map[string]string{
+				node.PhaseKey:           "Ready",
+				node.HostKey:            "host1",
+				node.SoftwareVersionKey: "v23.3.1",
+				node.ChassisKey:         "7220 IXR-D3",
+				"other":                 "value",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := invv1alpha1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			cr := &invv1alpha1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "node1",
+					Annotations: tc.annotations,
+				},
+			}
+			patches := 0
+			c := fake.NewClientBuilder().
+				WithScheme(s).
+				WithObjects(cr).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						patches++
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).
+				Build()
+			r := &reconciler{Client: c, scheme: s}
+
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(cr), cr); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.setStatus(context.Background(), cr, tc.phase, podWorkload{}, tc.obj, tc.info); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if patches != tc.wantPatches {
+				t.Errorf("want %d patches, got: %d", tc.wantPatches, patches)
+			}
+
+			got := &invv1alpha1.Node{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(cr), got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got.GetAnnotations()); diff != "" {
+				t.Errorf("annotations -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
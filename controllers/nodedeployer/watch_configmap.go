@@ -0,0 +1,100 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type configMapEventHandler struct {
+	client client.Client
+}
+
+// Create enqueues a request for all nodes that use the config map
+func (e *configMapEventHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	e.add(ctx, evt.Object, q)
+}
+
+// Update enqueues a request for all nodes that use the config map
+func (e *configMapEventHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	e.add(ctx, evt.ObjectNew, q)
+}
+
+// Delete enqueues a request for all nodes that use the config map
+func (e *configMapEventHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	e.add(ctx, evt.Object, q)
+}
+
+// Generic enqueues a request for all nodes that use the config map
+func (e *configMapEventHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	e.add(ctx, evt.Object, q)
+}
+
+// add enqueues the nodes of the provider for the config maps of a provider, which are
+// named <provider>-<name> e.g. srlinux.nokia.com-variants, and the nodes that use the
+// config map as startup config template. The config maps of a provider in the namespace
+// of the operator apply to the nodes in all namespaces.
+func (e *configMapEventHandler) add(ctx context.Context, obj runtime.Object, queue adder) {
+	cr, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+	l := log.FromContext(ctx)
+
+	nodes := []types.NamespacedName{}
+	if provider, _, found := strings.Cut(cr.GetName(), "-"); found {
+		namespace := cr.GetNamespace()
+		if namespace == os.Getenv("POD_NAMESPACE") {
+			namespace = ""
+		}
+		providerNodes, err := getNodesOfProvider(ctx, e.client, provider, namespace)
+		if err != nil {
+			l.Error(err, "cannot get nodes of provider")
+			return
+		}
+		nodes = append(nodes, providerNodes...)
+	}
+
+	startupConfigNodes, err := getNodesOfNodeConfigs(ctx, e.client, cr.GetNamespace(), func(nc *invv1alpha1.NodeConfig) bool {
+		return nc.Spec.StartupConfig != nil && *nc.Spec.StartupConfig == cr.GetName()
+	})
+	if err != nil {
+		l.Error(err, "cannot get nodes of startup config")
+		return
+	}
+	nodes = append(nodes, startupConfigNodes...)
+
+	if len(nodes) > 0 {
+		l.Info("event", "kind", obj.GetObjectKind(), "name", cr.GetName())
+	}
+	for _, n := range nodes {
+		l.Info("event requeue node", "name", n.Name)
+		queue.Add(reconcile.Request{NamespacedName: n})
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+
+	nodev1alpha1 "github.com/henderiw-nephio/network-node-operator/apis/node/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type nodeProviderEventHandler struct {
+	client client.Client
+}
+
+// Create enqueues a request for all nodes of the provider
+func (e *nodeProviderEventHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	e.add(ctx, evt.Object, q)
+}
+
+// Update enqueues a request for all nodes of the provider
+func (e *nodeProviderEventHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	e.add(ctx, evt.ObjectNew, q)
+}
+
+// Delete enqueues a request for all nodes of the provider
+func (e *nodeProviderEventHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	e.add(ctx, evt.Object, q)
+}
+
+// Generic enqueues a request for all nodes of the provider
+func (e *nodeProviderEventHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	e.add(ctx, evt.Object, q)
+}
+
+func (e *nodeProviderEventHandler) add(ctx context.Context, obj runtime.Object, queue adder) {
+	cr, ok := obj.(*nodev1alpha1.NodeProvider)
+	if !ok {
+		return
+	}
+	l := log.FromContext(ctx)
+	l.Info("event", "kind", obj.GetObjectKind(), "name", cr.GetName())
+
+	nodes, err := getNodesOfProvider(ctx, e.client, cr.GetName(), "")
+	if err != nil {
+		l.Error(err, "cannot get nodes of node provider")
+		return
+	}
+	for _, n := range nodes {
+		l.Info("event requeue node", "name", n.Name)
+		queue.Add(reconcile.Request{NamespacedName: n})
+	}
+}
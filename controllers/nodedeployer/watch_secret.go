@@ -0,0 +1,105 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"strings"
+
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// licenseSecretPrefix is the prefix of the license secrets e.g. licenses.srl.nokia.com
+	licenseSecretPrefix = "licenses."
+)
+
+type secretEventHandler struct {
+	client client.Client
+}
+
+// Create enqueues a request for all nodes that use the secret
+func (e *secretEventHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	e.add(ctx, evt.Object, q)
+}
+
+// Update enqueues a request for all nodes that use the secret
+func (e *secretEventHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	e.add(ctx, evt.ObjectNew, q)
+}
+
+// Delete enqueues a request for all nodes that use the secret
+func (e *secretEventHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	e.add(ctx, evt.Object, q)
+}
+
+// Generic enqueues a request for all nodes that use the secret
+func (e *secretEventHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	e.add(ctx, evt.Object, q)
+}
+
+// add enqueues the nodes that use the secret, which are
+// - the node with the name of the secret for the certificate secret
+// - the nodes of the provider with the name of the secret for the credential secret
+// - the nodes that have a license key for the license secrets
+func (e *secretEventHandler) add(ctx context.Context, obj runtime.Object, queue adder) {
+	cr, ok := obj.(*corev1.Secret)
+	if !ok {
+		return
+	}
+	l := log.FromContext(ctx)
+
+	nodes := []types.NamespacedName{}
+	n := &invv1alpha1.Node{}
+	if err := e.client.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}, n); err == nil {
+		nodes = append(nodes, types.NamespacedName{Namespace: n.GetNamespace(), Name: n.GetName()})
+	}
+
+	providerNodes, err := getNodesOfProvider(ctx, e.client, cr.GetName(), cr.GetNamespace())
+	if err != nil {
+		l.Error(err, "cannot get nodes of provider")
+		return
+	}
+	nodes = append(nodes, providerNodes...)
+
+	if strings.HasPrefix(cr.GetName(), licenseSecretPrefix) {
+		licenseNodes, err := getNodesOfNodeConfigs(ctx, e.client, cr.GetNamespace(), func(nc *invv1alpha1.NodeConfig) bool {
+			return nc.Spec.LicenseKey != nil
+		})
+		if err != nil {
+			l.Error(err, "cannot get nodes of license")
+			return
+		}
+		nodes = append(nodes, licenseNodes...)
+	}
+
+	if len(nodes) > 0 {
+		l.Info("event", "kind", obj.GetObjectKind(), "name", cr.GetName())
+	}
+	for _, n := range nodes {
+		l.Info("event requeue node", "name", n.Name)
+		queue.Add(reconcile.Request{NamespacedName: n})
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodedeployer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// workload defines the kind of object that runs a node. The nodes of the vm provider
+// type run in a kubevirt virtual machine instance, the other nodes run in a pod.
+type workload interface {
+	// kind returns the kind of the workload
+	kind() string
+	// newObject returns an empty object of the kind of the workload
+	newObject() client.Object
+	// getObject returns the workload of the node rendered by the provider
+	getObject(ctx context.Context, n node.Node, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (client.Object, error)
+	// getIPs returns the ips of the workload
+	getIPs(o client.Object) []corev1.PodIP
+	// getHost returns the host the workload is scheduled on
+	getHost(o client.Object) string
+	// setHost pins the workload to the host
+	setHost(o client.Object, host string) error
+	// getStatus returns the ips of the workload, the phase of the node derived from
+	// the workload and a message when the workload is not ready
+	getStatus(o client.Object) ([]corev1.PodIP, node.Phase, string, bool)
+}
+
+// getWorkload returns the workload of the nodes of the provider type.
+func getWorkload(providerType node.ProviderType) workload {
+	if providerType == node.ProviderTypeVM {
+		return vmiWorkload{}
+	}
+	return podWorkload{}
+}
+
+// getOwnedWorkloads returns an object of every workload kind that runs the nodes of
+// the registered providers. The pod workload is always owned. The other workload kinds
+// are only owned when their kind is served by the cluster, such that a missing crd,
+// e.g. kubevirt is not installed, does not fail the controller. The kinds are resolved
+// once at setup, the providers that node providers register at runtime are of the
+// network or server type and run in a pod, hence they never add a workload kind.
+func getOwnedWorkloads(ctx context.Context, nr node.NodeRegistry, c client.Client, s *runtime.Scheme, mapper meta.RESTMapper) []client.Object {
+	l := log.FromContext(ctx)
+	kinds := map[string]struct{}{}
+	objs := []client.Object{}
+	addWorkload := func(w workload) {
+		if _, ok := kinds[w.kind()]; ok {
+			return
+		}
+		kinds[w.kind()] = struct{}{}
+		o := w.newObject()
+		if _, ok := w.(podWorkload); !ok {
+			gvk := o.GetObjectKind().GroupVersionKind()
+			if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+				l.Info("workload kind not served, nodes of this kind are not watched", "gvk", gvk.String(), "err", err.Error())
+				return
+			}
+		}
+		objs = append(objs, o)
+	}
+	addWorkload(podWorkload{})
+	for _, provider := range nr.GetProviders() {
+		n, err := nr.NewNodeOfProvider(provider, c, s)
+		if err != nil {
+			continue
+		}
+		addWorkload(getWorkload(n.GetProviderType(ctx)))
+	}
+	return objs
+}
+
+type podWorkload struct{}
+
+func (podWorkload) kind() string { return "Pod" }
+
+func (podWorkload) newObject() client.Object { return &corev1.Pod{} }
+
+func (podWorkload) getObject(ctx context.Context, n node.Node, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (client.Object, error) {
+	pod, err := n.GetPodSpec(ctx, cr, nc, nads)
+	if err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+func (podWorkload) getIPs(o client.Object) []corev1.PodIP {
+	pod, ok := o.(*corev1.Pod)
+	if !ok {
+		return nil
+	}
+	return pod.Status.PodIPs
+}
+
+func (podWorkload) getHost(o client.Object) string {
+	pod, ok := o.(*corev1.Pod)
+	if !ok {
+		return ""
+	}
+	return pod.Spec.NodeName
+}
+
+func (podWorkload) setHost(o client.Object, host string) error {
+	pod, ok := o.(*corev1.Pod)
+	if !ok {
+		return fmt.Errorf("unexpected pod type %T", o)
+	}
+	if pod.Spec.NodeSelector == nil {
+		pod.Spec.NodeSelector = map[string]string{}
+	}
+	pod.Spec.NodeSelector[corev1.LabelHostname] = host
+	return nil
+}
+
+func (podWorkload) getStatus(o client.Object) ([]corev1.PodIP, node.Phase, string, bool) {
+	pod, ok := o.(*corev1.Pod)
+	if !ok {
+		return nil, node.PhasePending, fmt.Sprintf("unexpected pod type %T", o), false
+	}
+	return getPodStatus(pod)
+}
+
+type vmiWorkload struct{}
+
+func (vmiWorkload) kind() string { return node.VirtualMachineInstanceGroupVersionKind.Kind }
+
+func (vmiWorkload) newObject() client.Object { return node.NewVirtualMachineInstance() }
+
+func (vmiWorkload) getObject(ctx context.Context, n node.Node, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (client.Object, error) {
+	vmNode, ok := n.(node.VirtualMachineNode)
+	if !ok {
+		return nil, fmt.Errorf("provider %s of type %s does not provide a virtual machine instance", cr.Spec.Provider, node.ProviderTypeVM)
+	}
+	vmi, err := vmNode.GetVirtualMachineInstanceSpec(ctx, cr, nc, nads)
+	if err != nil {
+		return nil, err
+	}
+	return vmi, nil
+}
+
+// getIPs returns the ips of the interface of the virtual machine instance that is
+// connected to the pod network.
+func (vmiWorkload) getIPs(o client.Object) []corev1.PodIP {
+	vmi, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	itfces, _, _ := unstructured.NestedSlice(vmi.Object, "status", "interfaces")
+	for _, itfce := range itfces {
+		itfce, ok := itfce.(map[string]any)
+		if !ok {
+			continue
+		}
+		if name, _, _ := unstructured.NestedString(itfce, "name"); name != node.VirtualMachineInstancePodNetworkName {
+			continue
+		}
+		ips, _, _ := unstructured.NestedStringSlice(itfce, "ipAddresses")
+		if len(ips) == 0 {
+			if ip, _, _ := unstructured.NestedString(itfce, "ipAddress"); ip != "" {
+				ips = []string{ip}
+			}
+		}
+		podIPs := make([]corev1.PodIP, 0, len(ips))
+		for _, ip := range ips {
+			podIPs = append(podIPs, corev1.PodIP{IP: ip})
+		}
+		return podIPs
+	}
+	return nil
+}
+
+func (vmiWorkload) getHost(o client.Object) string {
+	vmi, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return ""
+	}
+	host, _, _ := unstructured.NestedString(vmi.Object, "status", "nodeName")
+	return host
+}
+
+func (vmiWorkload) setHost(o client.Object, host string) error {
+	vmi, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected virtual machine instance type %T", o)
+	}
+	return unstructured.SetNestedField(vmi.Object, host, "spec", "nodeSelector", corev1.LabelHostname)
+}
+
+// getStatus derives the phase of the node from the phase and the ready condition of
+// the virtual machine instance.
+func (w vmiWorkload) getStatus(o client.Object) ([]corev1.PodIP, node.Phase, string, bool) {
+	vmi, ok := o.(*unstructured.Unstructured)
+	if !ok {
+		return nil, node.PhasePending, fmt.Sprintf("unexpected virtual machine instance type %T", o), false
+	}
+	phase, _, _ := unstructured.NestedString(vmi.Object, "status", "phase")
+	switch phase {
+	case "", "Pending", "Scheduling":
+		return nil, node.PhasePending, "virtual machine instance not scheduled", false
+	case "Scheduled":
+		return nil, node.PhasePodScheduled, "virtual machine instance not running", false
+	case "Running":
+	default:
+		return nil, node.PhasePending, fmt.Sprintf("virtual machine instance %s", phase), false
+	}
+	if !isVMIReady(vmi) {
+		return nil, node.PhaseBooting, "virtual machine instance not ready", false
+	}
+	ips := w.getIPs(vmi)
+	if len(ips) == 0 {
+		return nil, node.PhaseBooting, "no ip provided", false
+	}
+	return ips, node.PhaseReady, "", true
+}
+
+func isVMIReady(vmi *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(vmi.Object, "status", "conditions")
+	for _, c := range conditions {
+		c, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		t, _, _ := unstructured.NestedString(c, "type")
+		status, _, _ := unstructured.NestedString(c, "status")
+		if t == "Ready" && status == string(corev1.ConditionTrue) {
+			return true
+		}
+	}
+	return false
+}
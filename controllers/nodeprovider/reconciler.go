@@ -0,0 +1,134 @@
+/*
+Copyright 2022 Nokia.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeprovider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	nodev1alpha1 "github.com/henderiw-nephio/network-node-operator/apis/node/v1alpha1"
+	"github.com/henderiw-nephio/network-node-operator/controllers"
+	"github.com/henderiw-nephio/network-node-operator/controllers/ctrlconfig"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/generic"
+	"github.com/nephio-project/nephio/controllers/pkg/resource"
+	resourcev1alpha1 "github.com/nokia/k8s-ipam/apis/resource/common/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+func init() {
+	controllers.Register("nodeprovider", &reconciler{})
+}
+
+const (
+	// errors
+	errGetCr        = "cannot get cr"
+	errUpdateStatus = "cannot update status"
+)
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, c interface{}) (map[schema.GroupVersionKind]chan event.GenericEvent, error) {
+	cfg, ok := c.(*ctrlconfig.ControllerConfig)
+	if !ok {
+		return nil, fmt.Errorf("cannot initialize, expecting controllerConfig, got: %s", reflect.TypeOf(c).Name())
+	}
+
+	if err := nodev1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
+		return nil, err
+	}
+
+	r.Client = mgr.GetClient()
+	r.config = mgr.GetConfig()
+	r.nodeRegistry = cfg.Noderegistry
+	r.providers = map[string]struct{}{}
+
+	// the status updates of the node provider do not change the generation
+	return nil, ctrl.NewControllerManagedBy(mgr).
+		Named("NodeProviderController").
+		WithOptions(cfg.Copts).
+		For(&nodev1alpha1.NodeProvider{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// reconciler registers the node providers in the node registry
+type reconciler struct {
+	client.Client
+	config       *rest.Config
+	nodeRegistry node.NodeRegistry
+
+	// providers are the providers that are registered by the reconciler,
+	// these are the only providers the reconciler unregisters or overrides
+	m         sync.Mutex
+	providers map[string]struct{}
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := log.FromContext(ctx)
+	l.Info("reconcile", "req", req)
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	cr := &nodev1alpha1.NodeProvider{}
+	if err := r.Get(ctx, req.NamespacedName, cr); err != nil {
+		// if the resource no longer exists the reconcile loop is done
+		if resource.IgnoreNotFound(err) != nil {
+			l.Error(err, errGetCr)
+			return ctrl.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetCr)
+		}
+		// the nodes of the provider become unsupported
+		if _, ok := r.providers[req.Name]; ok {
+			l.Info("unregister provider", "provider", req.Name)
+			r.nodeRegistry.Unregister(req.Name)
+			delete(r.providers, req.Name)
+		}
+		return ctrl.Result{}, nil
+	}
+	cr = cr.DeepCopy()
+
+	if resource.WasDeleted(cr) {
+		if _, ok := r.providers[cr.GetName()]; ok {
+			l.Info("unregister provider", "provider", cr.GetName())
+			r.nodeRegistry.Unregister(cr.GetName())
+			delete(r.providers, cr.GetName())
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// the providers that are compiled in cannot be overridden
+	if _, ok := r.providers[cr.GetName()]; !ok && r.nodeRegistry.IsRegistered(cr.GetName()) {
+		cr.SetConditions(resourcev1alpha1.Failed(fmt.Sprintf("provider %q is a builtin provider and cannot be overridden", cr.GetName())))
+		return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+	}
+
+	l.Info("register provider", "provider", cr.GetName())
+	generic.Register(r.nodeRegistry, cr, r.config)
+	r.providers[cr.GetName()] = struct{}{}
+
+	cr.SetConditions(resourcev1alpha1.Ready())
+	return ctrl.Result{}, errors.Wrap(r.Status().Update(ctx, cr), errUpdateStatus)
+}
@@ -21,18 +21,34 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/henderiw-nephio/network-node-operator/controllers/ctrlconfig"
 	_ "github.com/henderiw-nephio/network-node-operator/controllers/nodedeployer"
+	_ "github.com/henderiw-nephio/network-node-operator/controllers/nodeprovider"
 	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/ceos"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/frr"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/kubevirt"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/plugin"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/sonic"
 	"github.com/henderiw-nephio/network-node-operator/pkg/node/srlinux"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/sros"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/xrd"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/xserver"
+	"github.com/henderiw-nephio/network-node-operator/pkg/webhook"
 
 	"github.com/henderiw-nephio/network-node-operator/controllers"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
@@ -45,8 +61,17 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var maxConcurrentReconciles int
+	var maxConcurrentInitialConfigs int
+	var poll time.Duration
+	var pluginDir string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1, "The maximum number of concurrent reconciles per controller.")
+	flag.IntVar(&maxConcurrentInitialConfigs, "max-concurrent-initial-configs", 4,
+		"The maximum number of concurrent initial config sessions per node provider, 0 is unbounded.")
+	flag.DurationVar(&poll, "poll", 5*time.Second, "The interval to poll the status of a node that is not ready.")
+	flag.StringVar(&pluginDir, "plugin-dir", plugin.DefaultDir, "The directory with the configs of the node provider plugins.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -65,8 +90,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// only the secrets and config maps that are labeled for the operator are cached,
+	// instead of all the secrets and config maps of the cluster. The cache only triggers
+	// the watches, the secrets and config maps are read from the api server such that
+	// the unlabeled secrets and config maps of existing deployments are still found.
+	watchSelector := labels.SelectorFromSet(labels.Set{node.WatchLabelKey: node.WatchLabelValue})
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                     scheme,
+		Scheme: scheme,
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.Secret{}:    {Label: watchSelector},
+				&corev1.ConfigMap{}: {Label: watchSelector},
+			},
+		},
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				DisableFor: []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}},
+			},
+		},
 		MetricsBindAddress:         metricsAddr,
 		Port:                       9443,
 		HealthProbeBindAddress:     probeAddr,
@@ -82,11 +123,25 @@ func main() {
 	setupLog.Info("setup controller")
 	ctx := ctrl.SetupSignalHandler()
 
+	nodeRegistry := registerSupportedNodeProviders()
+	// the plugins are registered next to the providers that are compiled in
+	providers, err := plugin.Discover(ctx, nodeRegistry, pluginDir)
+	if err != nil {
+		setupLog.Error(err, "cannot discover node provider plugins", "dir", pluginDir)
+		os.Exit(1)
+	}
+	setupLog.Info("plugins", "dir", pluginDir, "providers", providers)
+
 	for name, reconciler := range controllers.Reconcilers {
 		setupLog.Info("reconciler", "name", name, "enabled", IsReconcilerEnabled(name))
 		if IsReconcilerEnabled(name) {
 			if _, err := reconciler.SetupWithManager(ctx, mgr, &ctrlconfig.ControllerConfig{
-				Noderegistry: registerSupportedNodeProviders(),
+				Poll: poll,
+				Copts: controller.Options{
+					MaxConcurrentReconciles: maxConcurrentReconciles,
+				},
+				Noderegistry:                nodeRegistry,
+				MaxConcurrentInitialConfigs: maxConcurrentInitialConfigs,
 			}); err != nil {
 				setupLog.Error(err, "cannot add controllers to manager")
 				os.Exit(1)
@@ -94,6 +149,15 @@ func main() {
 		}
 	}
 
+	// the webhooks are served by the webhook server of the manager on port 9443
+	setupLog.Info("webhooks", "enabled", IsWebhookEnabled())
+	if IsWebhookEnabled() {
+		if err := webhook.Setup(mgr, nodeRegistry); err != nil {
+			setupLog.Error(err, "cannot add webhooks to manager")
+			os.Exit(1)
+		}
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -119,9 +183,20 @@ func IsReconcilerEnabled(reconcilerName string) bool {
 	return false
 }
 
+func IsWebhookEnabled() bool {
+	return os.Getenv("ENABLE_WEBHOOKS") == "true"
+}
+
 func registerSupportedNodeProviders() node.NodeRegistry {
 	nodeRegistry := node.NewNodeRegistry()
 	srlinux.Register(nodeRegistry)
+	sros.Register(nodeRegistry)
+	xserver.Register(nodeRegistry)
+	ceos.Register(nodeRegistry)
+	sonic.Register(nodeRegistry)
+	frr.Register(nodeRegistry)
+	xrd.Register(nodeRegistry)
+	kubevirt.Register(nodeRegistry)
 
 	return nodeRegistry
 }
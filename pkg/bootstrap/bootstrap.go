@@ -0,0 +1,50 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+)
+
+// Update defines a configuration update of the initial config.
+// The path is a gnmi xpath e.g. /system/tls/server-profile[name=k8s-profile]
+// and the value is encoded as json_ietf.
+type Update struct {
+	Path  string
+	Value any
+}
+
+// Config defines the initial config of a node as structured data.
+type Config struct {
+	// Updates define the configuration updates that are applied in a single transaction
+	Updates []Update
+	// Tools define the operational updates that are applied after the configuration
+	// updates, e.g. to save the running config to the startup config
+	Tools []Update
+}
+
+// Transport pushes the initial config to a node.
+type Transport interface {
+	Push(ctx context.Context, cfg *Config) error
+}
+
+// NewFallbackTransport returns a transport that pushes the initial config
+// using the transports in order until a transport succeeds.
+func NewFallbackTransport(transports ...Transport) Transport {
+	return &fallbackTransport{transports: transports}
+}
+
+type fallbackTransport struct {
+	transports []Transport
+}
+
+func (r *fallbackTransport) Push(ctx context.Context, cfg *Config) error {
+	var errs error
+	for _, t := range r.transports {
+		err := t.Push(ctx, cfg)
+		if err == nil {
+			return nil
+		}
+		errs = errors.Join(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,57 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ExecConfig defines the container of a pod in which a command is executed.
+type ExecConfig struct {
+	Config    *rest.Config
+	Namespace string
+	Pod       string
+	Container string
+}
+
+// Exec executes the command in the container through the api server and returns
+// the stdout of the command. This is used by the nodes that have no management
+// interface to push the initial config to.
+func Exec(ctx context.Context, cfg ExecConfig, cmd []string) (string, error) {
+	clientset, err := kubernetes.NewForConfig(cfg.Config)
+	if err != nil {
+		return "", err
+	}
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(cfg.Namespace).
+		Name(cfg.Pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: cfg.Container,
+			Command:   cmd,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(cfg.Config, "POST", req.URL())
+	if err != nil {
+		return "", err
+	}
+	var stdout, stderr bytes.Buffer
+	if err := exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}); err != nil {
+		return "", fmt.Errorf("cannot execute %q in %s/%s, err: %w, stderr: %s",
+			strings.Join(cmd, " "), cfg.Pod, cfg.Container, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,166 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"time"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	defaultGNMITimeout = 30 * time.Second
+)
+
+// GNMIConfig defines the connection parameters of the gnmi transport.
+type GNMIConfig struct {
+	// Address of the gnmi server as a grpc target, e.g. 10.0.0.1:57400 or
+	// unix:///opt/srlinux/var/run/sr_gnmi_server
+	Address  string
+	Username string
+	Password string
+	// Insecure uses a plaintext connection, this is used to bootstrap
+	// the node before the tls certificates are provisioned
+	Insecure bool
+	// SkipVerify skips the verification of the server certificate
+	SkipVerify bool
+	// Timeout of the gnmi set requests
+	Timeout time.Duration
+}
+
+// NewGNMITransport returns a transport that pushes the initial config using gnmi set.
+func NewGNMITransport(cfg GNMIConfig) Transport {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultGNMITimeout
+	}
+	return &gnmiTransport{cfg: cfg}
+}
+
+type gnmiTransport struct {
+	cfg GNMIConfig
+}
+
+func (r *gnmiTransport) Push(ctx context.Context, cfg *Config) error {
+	conn, err := dial(ctx, r.cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "username", r.cfg.Username, "password", r.cfg.Password)
+
+	c := gnmi.NewGNMIClient(conn)
+	for _, updates := range [][]Update{cfg.Updates, cfg.Tools} {
+		if len(updates) == 0 {
+			continue
+		}
+		req, err := getSetRequest(updates)
+		if err != nil {
+			return err
+		}
+		if _, err := c.Set(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetValues reads the values of the leaf paths from the gnmi server, this is used
+// to read back the state of the node after the initial config is pushed.
+// The values are returned as strings keyed by the requested path.
+func GetValues(ctx context.Context, cfg GNMIConfig, paths ...string) (map[string]string, error) {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultGNMITimeout
+	}
+	conn, err := dial(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "username", cfg.Username, "password", cfg.Password)
+
+	c := gnmi.NewGNMIClient(conn)
+	values := make(map[string]string, len(paths))
+	for _, path := range paths {
+		p, err := ParsePath(path)
+		if err != nil {
+			return nil, err
+		}
+		rsp, err := c.Get(ctx, &gnmi.GetRequest{
+			Path:     []*gnmi.Path{p},
+			Type:     gnmi.GetRequest_STATE,
+			Encoding: gnmi.Encoding_JSON_IETF,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range rsp.GetNotification() {
+			for _, u := range n.GetUpdate() {
+				values[path] = getValue(u.GetVal())
+			}
+		}
+	}
+	return values, nil
+}
+
+func dial(ctx context.Context, cfg GNMIConfig) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if !cfg.Insecure {
+		//nolint:gosec
+		creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: cfg.SkipVerify})
+	}
+	return grpc.DialContext(ctx, cfg.Address, grpc.WithTransportCredentials(creds))
+}
+
+// getValue returns the value as a string, json encoded strings are unquoted
+func getValue(v *gnmi.TypedValue) string {
+	switch v.GetValue().(type) {
+	case *gnmi.TypedValue_JsonIetfVal:
+		return getJSONValue(v.GetJsonIetfVal())
+	case *gnmi.TypedValue_JsonVal:
+		return getJSONValue(v.GetJsonVal())
+	case *gnmi.TypedValue_StringVal:
+		return v.GetStringVal()
+	default:
+		return v.String()
+	}
+}
+
+func getJSONValue(b []byte) string {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return string(b)
+	}
+	return s
+}
+
+func getSetRequest(updates []Update) (*gnmi.SetRequest, error) {
+	req := &gnmi.SetRequest{}
+	for _, u := range updates {
+		p, err := ParsePath(u.Path)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(u.Value)
+		if err != nil {
+			return nil, err
+		}
+		req.Update = append(req.Update, &gnmi.Update{
+			Path: p,
+			Val: &gnmi.TypedValue{
+				Value: &gnmi.TypedValue_JsonIetfVal{JsonIetfVal: b},
+			},
+		})
+	}
+	return req, nil
+}
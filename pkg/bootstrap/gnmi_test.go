@@ -0,0 +1,163 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeGNMIServer records the set requests it receives
+type fakeGNMIServer struct {
+	gnmi.UnimplementedGNMIServer
+	username string
+	password string
+	requests []*gnmi.SetRequest
+}
+
+func (s *fakeGNMIServer) Set(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get("username")) == 0 || md.Get("username")[0] != s.username ||
+		len(md.Get("password")) == 0 || md.Get("password")[0] != s.password {
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	s.requests = append(s.requests, req)
+	return &gnmi.SetResponse{}, nil
+}
+
+func startFakeGNMIServer(t *testing.T, s *fakeGNMIServer) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot listen: %s", err)
+	}
+	gs := grpc.NewServer()
+	gnmi.RegisterGNMIServer(gs, s)
+	go func() {
+		_ = gs.Serve(l)
+	}()
+	t.Cleanup(gs.Stop)
+	return l.Addr().String()
+}
+
+func TestGNMITransport(t *testing.T) {
+	cfg := &Config{
+		Updates: []Update{
+			{Path: "/system/lldp", Value: map[string]any{"admin-state": "enable"}},
+			{Path: "/system/tls/server-profile[name=k8s-profile]", Value: map[string]any{"authenticate-client": false}},
+		},
+		Tools: []Update{
+			{Path: "/tools/system/configuration/save", Value: map[string]any{}},
+		},
+	}
+
+	cases := map[string]struct {
+		username      string
+		password      string
+		wantErr       bool
+		wantRequests  int
+		wantUpdates   []string
+		wantJsonValue string
+	}{
+		"Valid": {
+			username:      "admin",
+			password:      "secret",
+			wantRequests:  2,
+			wantUpdates:   []string{"system", "system"},
+			wantJsonValue: `{"admin-state":"enable"}`,
+		},
+		"InvalidCredentials": {
+			username: "admin",
+			password: "wrong",
+			wantErr:  true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &fakeGNMIServer{username: "admin", password: "secret"}
+			addr := startFakeGNMIServer(t, s)
+
+			err := NewGNMITransport(GNMIConfig{
+				Address:  addr,
+				Username: tc.username,
+				Password: tc.password,
+				Insecure: true,
+			}).Push(context.Background(), cfg)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(s.requests) != tc.wantRequests {
+				t.Fatalf("expected %d set requests, got %d", tc.wantRequests, len(s.requests))
+			}
+			gotUpdates := []string{}
+			for _, u := range s.requests[0].GetUpdate() {
+				gotUpdates = append(gotUpdates, u.GetPath().GetElem()[0].GetName())
+			}
+			if diff := cmp.Diff(tc.wantUpdates, gotUpdates); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantJsonValue, string(s.requests[0].GetUpdate()[0].GetVal().GetJsonIetfVal())); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+type fakeTransport struct {
+	err    error
+	pushed int
+}
+
+func (r *fakeTransport) Push(ctx context.Context, cfg *Config) error {
+	r.pushed++
+	return r.err
+}
+
+func TestFallbackTransport(t *testing.T) {
+	cases := map[string]struct {
+		primaryErr   error
+		fallbackErr  error
+		wantErr      bool
+		wantFallback int
+	}{
+		"Primary": {
+			wantFallback: 0,
+		},
+		"Fallback": {
+			primaryErr:   errors.New("gnmi not available"),
+			wantFallback: 1,
+		},
+		"BothFail": {
+			primaryErr:   errors.New("gnmi not available"),
+			fallbackErr:  errors.New("ssh not available"),
+			wantErr:      true,
+			wantFallback: 1,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			primary := &fakeTransport{err: tc.primaryErr}
+			fallback := &fakeTransport{err: tc.fallbackErr}
+			err := NewFallbackTransport(primary, fallback).Push(context.Background(), &Config{})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("want error %t, got: %v", tc.wantErr, err)
+			}
+			if fallback.pushed != tc.wantFallback {
+				t.Errorf("want fallback pushed %d, got: %d", tc.wantFallback, fallback.pushed)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package bootstrap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// ParsePath parses a gnmi xpath, e.g. /interface[name=ethernet-1/1]/description,
+// into a gnmi path. Key values can contain a '/' and a ']' is escaped as '\]'.
+func ParsePath(p string) (*gnmi.Path, error) {
+	elems := []*gnmi.PathElem{}
+	for _, e := range splitPath(p) {
+		if e == "" {
+			continue
+		}
+		elem, err := parsePathElem(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %s, err: %w", p, err)
+		}
+		elems = append(elems, elem)
+	}
+	return &gnmi.Path{Elem: elems}, nil
+}
+
+// splitPath splits the path in elements on '/' outside of the keys
+func splitPath(p string) []string {
+	elems := []string{}
+	var sb strings.Builder
+	inKey := false
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; {
+		case c == '\\' && i+1 < len(p):
+			sb.WriteByte(c)
+			sb.WriteByte(p[i+1])
+			i++
+		case c == '[':
+			inKey = true
+			sb.WriteByte(c)
+		case c == ']':
+			inKey = false
+			sb.WriteByte(c)
+		case c == '/' && !inKey:
+			elems = append(elems, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return append(elems, sb.String())
+}
+
+func parsePathElem(e string) (*gnmi.PathElem, error) {
+	idx := strings.Index(e, "[")
+	if idx == -1 {
+		return &gnmi.PathElem{Name: e}, nil
+	}
+	elem := &gnmi.PathElem{Name: e[:idx], Key: map[string]string{}}
+	keys := e[idx:]
+	for len(keys) > 0 {
+		if keys[0] != '[' {
+			return nil, fmt.Errorf("invalid key in elem %s", e)
+		}
+		end := keyEnd(keys)
+		if end == -1 {
+			return nil, fmt.Errorf("unterminated key in elem %s", e)
+		}
+		kv := strings.SplitN(keys[1:end], "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid key %s in elem %s", keys[1:end], e)
+		}
+		elem.Key[kv[0]] = strings.ReplaceAll(kv[1], `\]`, "]")
+		keys = keys[end+1:]
+	}
+	return elem, nil
+}
+
+// keyEnd returns the index of the closing bracket of the key, skipping escaped brackets
+func keyEnd(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ']':
+			return i
+		}
+	}
+	return -1
+}
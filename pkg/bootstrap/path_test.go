@@ -0,0 +1,78 @@
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestParsePath(t *testing.T) {
+	cases := map[string]struct {
+		input       string
+		want        *gnmi.Path
+		expectedErr bool
+	}{
+		"Simple": {
+			input: "/system/lldp",
+			want: &gnmi.Path{Elem: []*gnmi.PathElem{
+				{Name: "system"},
+				{Name: "lldp"},
+			}},
+		},
+		"Key": {
+			input: "/system/tls/server-profile[name=k8s-profile]",
+			want: &gnmi.Path{Elem: []*gnmi.PathElem{
+				{Name: "system"},
+				{Name: "tls"},
+				{Name: "server-profile", Key: map[string]string{"name": "k8s-profile"}},
+			}},
+		},
+		"KeyWithSlash": {
+			input: "/interface[name=ethernet-1/1]/subinterface[index=0]/description",
+			want: &gnmi.Path{Elem: []*gnmi.PathElem{
+				{Name: "interface", Key: map[string]string{"name": "ethernet-1/1"}},
+				{Name: "subinterface", Key: map[string]string{"index": "0"}},
+				{Name: "description"},
+			}},
+		},
+		"MultipleKeys": {
+			input: "/a[x=1][y=2]",
+			want: &gnmi.Path{Elem: []*gnmi.PathElem{
+				{Name: "a", Key: map[string]string{"x": "1", "y": "2"}},
+			}},
+		},
+		"EscapedBracket": {
+			input: `/a[x=1\]2]`,
+			want: &gnmi.Path{Elem: []*gnmi.PathElem{
+				{Name: "a", Key: map[string]string{"x": "1]2"}},
+			}},
+		},
+		"Unterminated": {
+			input:       "/a[x=1",
+			expectedErr: true,
+		},
+		"InvalidKey": {
+			input:       "/a[x]",
+			expectedErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := ParsePath(tc.input)
+			if tc.expectedErr {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, p, protocmp.Transform()); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
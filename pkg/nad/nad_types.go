@@ -2,6 +2,7 @@ package nad
 
 import (
 	"encoding/json"
+	"fmt"
 
 	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 )
@@ -9,8 +10,21 @@ import (
 const (
 	CniVersion = "0.3.1"
 	// cni types
-	WirePluginType   = "wire"
-	TuningPluginType = "tuning"
+	WirePluginType       = "wire"
+	TuningPluginType     = "tuning"
+	MacvlanPluginType    = "macvlan"
+	BridgePluginType     = "bridge"
+	HostDevicePluginType = "host-device"
+	VxlanPluginType      = "vxlan"
+	SriovPluginType      = "sriov"
+	// ipam types
+	HostLocalIPAMType   = "host-local"
+	StaticIPAMType      = "static"
+	DHCPIPAMType        = "dhcp"
+	WhereaboutsIPAMType = "whereabouts"
+	// InterfaceNameKey is the annotation of a nad with the name of the interface
+	// in the container
+	InterfaceNameKey = "node.nephio.com/interface-name"
 )
 
 type NadConfig struct {
@@ -18,14 +32,12 @@ type NadConfig struct {
 	Plugins    []map[string]any `json:"plugins,omitempty"`
 }
 
-// key = type; plugin should become a init fn
-var Plugins = map[string]PluginConfigInterface{
-	"WirePlugin":   WirePlugin{},
-	"TuningPlugin": TuningPlugin{},
+// PluginConfigInterface is implemented by the config of every cni plugin through
+// the embedded PluginCniType.
+type PluginConfigInterface interface {
+	GetType() string
 }
 
-type PluginConfigInterface interface{}
-
 type WirePlugin struct {
 	PluginCniType
 	InterfaceName string `json:"interfaceName,omitempty"`
@@ -47,6 +59,11 @@ type PluginCniType struct {
 	Name string `json:"name,omitempty"`
 }
 
+// GetType returns the cni type of the plugin.
+func (r PluginCniType) GetType() string {
+	return r.Type
+}
+
 func GetNadConfig(plugins []PluginConfigInterface) ([]byte, error) {
 	nadConfig := NadConfig{
 		CniVersion: CniVersion,
@@ -67,11 +84,35 @@ func GetNadConfig(plugins []PluginConfigInterface) ([]byte, error) {
 	return json.Marshal(nadConfig)
 }
 
+// GetInterfaceName returns the name of the interface in the container as recorded in
+// the InterfaceNameKey annotation of the nad, or else as defined by the first plugin of
+// the network attachment definition config that sets it.
+func GetInterfaceName(nad *nadv1.NetworkAttachmentDefinition) (string, error) {
+	if name := nad.GetAnnotations()[InterfaceNameKey]; name != "" {
+		return name, nil
+	}
+	nadConfig := NadConfig{}
+	if err := json.Unmarshal([]byte(nad.Spec.Config), &nadConfig); err != nil {
+		return "", fmt.Errorf("cannot parse config of nad %s, err: %w", nad.GetName(), err)
+	}
+	for _, plugin := range nadConfig.Plugins {
+		if name, ok := plugin["interfaceName"].(string); ok && name != "" {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("nad %s does not define an interface name", nad.GetName())
+}
+
+// GetNadAnnotation returns the multus network selection annotation of the nads. The
+// interface name in the container is set for the nads that define it, since the cni
+// plugins other than wire do not name the interface themselves.
 func GetNadAnnotation(nads []*nadv1.NetworkAttachmentDefinition) ([]byte, error) {
 	a := []NadAnnotationEntry{}
 	for _, nad := range nads {
+		itfceName, _ := GetInterfaceName(nad)
 		a = append(a, NadAnnotationEntry{
-			Name: nad.GetName(),
+			Name:      nad.GetName(),
+			Interface: itfceName,
 		})
 	}
 	return json.Marshal(a)
@@ -80,5 +121,6 @@ func GetNadAnnotation(nads []*nadv1.NetworkAttachmentDefinition) ([]byte, error)
 type NadAnnotation []NadAnnotationEntry
 
 type NadAnnotationEntry struct {
-	Name string `json:"name,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Interface string `json:"interface,omitempty"`
 }
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetNadConfig(t *testing.T) {
@@ -42,6 +44,92 @@ func TestGetNadConfig(t *testing.T) {
 			},
 			want: `{"cniVersion":"0.3.1","plugins":[{"interfaceName":"e1-1","type":"wire"},{"mac":true,"type":"tuning"}]}`,
 		},
+		"Macvlan": {
+			input: []PluginConfigInterface{
+				MacvlanPlugin{
+					PluginCniType: PluginCniType{
+						Type: MacvlanPluginType,
+					},
+					Master: "eth1",
+					Mode:   "bridge",
+					MTU:    9000,
+					IPAM: &IPAM{
+						Type:       HostLocalIPAMType,
+						Subnet:     "10.0.0.0/24",
+						RangeStart: "10.0.0.10",
+						RangeEnd:   "10.0.0.100",
+						Gateway:    "10.0.0.1",
+					},
+				},
+			},
+			want: `{"cniVersion":"0.3.1","plugins":[{"ipam":{"gateway":"10.0.0.1","rangeEnd":"10.0.0.100","rangeStart":"10.0.0.10","subnet":"10.0.0.0/24","type":"host-local"},"master":"eth1","mode":"bridge","mtu":9000,"type":"macvlan"}]}`,
+		},
+		"Bridge": {
+			input: []PluginConfigInterface{
+				BridgePlugin{
+					PluginCniType: PluginCniType{
+						Type: BridgePluginType,
+					},
+					Bridge:    "br0",
+					IsGateway: true,
+					VLAN:      100,
+					IPAM: &IPAM{
+						Type:  WhereaboutsIPAMType,
+						Range: "192.168.1.0/24",
+						Routes: []IPAMRoute{
+							{Dst: "0.0.0.0/0"},
+						},
+					},
+				},
+			},
+			want: `{"cniVersion":"0.3.1","plugins":[{"bridge":"br0","ipam":{"range":"192.168.1.0/24","routes":[{"dst":"0.0.0.0/0"}],"type":"whereabouts"},"isGateway":true,"type":"bridge","vlan":100}]}`,
+		},
+		"HostDevice": {
+			input: []PluginConfigInterface{
+				HostDevicePlugin{
+					PluginCniType: PluginCniType{
+						Type: HostDevicePluginType,
+					},
+					PCIBusID: "0000:3b:00.1",
+				},
+			},
+			want: `{"cniVersion":"0.3.1","plugins":[{"pciBusID":"0000:3b:00.1","type":"host-device"}]}`,
+		},
+		"Vxlan": {
+			input: []PluginConfigInterface{
+				VxlanPlugin{
+					PluginCniType: PluginCniType{
+						Type: VxlanPluginType,
+					},
+					InterfaceName: "e1-1",
+					VNI:           5001,
+					Remote:        "172.18.0.3",
+					Dev:           "eth0",
+					DstPort:       4789,
+					MTU:           1450,
+				},
+			},
+			want: `{"cniVersion":"0.3.1","plugins":[{"dev":"eth0","dstPort":4789,"interfaceName":"e1-1","mtu":1450,"remote":"172.18.0.3","type":"vxlan","vni":5001}]}`,
+		},
+		"Sriov": {
+			input: []PluginConfigInterface{
+				SriovPlugin{
+					PluginCniType: PluginCniType{
+						Type: SriovPluginType,
+					},
+					VLAN:     10,
+					SpoofChk: "off",
+					Trust:    "on",
+					IPAM: &IPAM{
+						Type: StaticIPAMType,
+						Addresses: []IPAMAddress{
+							{Address: "10.1.1.1/30", Gateway: "10.1.1.2"},
+						},
+					},
+				},
+			},
+			want: `{"cniVersion":"0.3.1","plugins":[{"ipam":{"addresses":[{"address":"10.1.1.1/30","gateway":"10.1.1.2"}],"type":"static"},"spoofchk":"off","trust":"on","type":"sriov","vlan":10}]}`,
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -55,3 +143,168 @@ func TestGetNadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetInterfaceName(t *testing.T) {
+	cases := map[string]struct {
+		annotations map[string]string
+		config      string
+		want        string
+		wantErr     bool
+	}{
+		"Wire": {
+			config: `{"cniVersion":"0.3.1","plugins":[{"interfaceName":"eth1","type":"wire"}]}`,
+			want:   "eth1",
+		},
+		"Annotation": {
+			annotations: map[string]string{InterfaceNameKey: "eth3"},
+			config:      `{"cniVersion":"0.3.1","plugins":[{"master":"eth1","type":"macvlan"}]}`,
+			want:        "eth3",
+		},
+		"Chained": {
+			config: `{"cniVersion":"0.3.1","plugins":[{"mac":true,"type":"tuning"},{"interfaceName":"eth2","type":"wire"}]}`,
+			want:   "eth2",
+		},
+		"NoInterfaceName": {
+			config:  `{"cniVersion":"0.3.1","plugins":[{"mac":true,"type":"tuning"}]}`,
+			wantErr: true,
+		},
+		"Invalid": {
+			config:  `{`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetInterfaceName(&nadv1.NetworkAttachmentDefinition{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec:       nadv1.NetworkAttachmentDefinitionSpec{Config: tc.config},
+			})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetNadAnnotation(t *testing.T) {
+	nads := []*nadv1.NetworkAttachmentDefinition{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1-e1-1"},
+			Spec:       nadv1.NetworkAttachmentDefinitionSpec{Config: `{"cniVersion":"0.3.1","plugins":[{"interfaceName":"eth1","type":"wire"}]}`},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1-e1-2", Annotations: map[string]string{InterfaceNameKey: "eth2"}},
+			Spec:       nadv1.NetworkAttachmentDefinitionSpec{Config: `{"cniVersion":"0.3.1","plugins":[{"master":"eth1","type":"macvlan"}]}`},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1-e1-3"},
+			Spec:       nadv1.NetworkAttachmentDefinitionSpec{Config: `{"cniVersion":"0.3.1","plugins":[{"type":"sriov"}]}`},
+		},
+	}
+	got, err := GetNadAnnotation(nads)
+	assert.NoError(t, err)
+	want := `[{"name":"node1-e1-1","interface":"eth1"},{"name":"node1-e1-2","interface":"eth2"},{"name":"node1-e1-3"}]`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("-want, +got:\n%s", diff)
+	}
+}
+
+func TestParsePluginConfig(t *testing.T) {
+	cases := map[string]struct {
+		config  string
+		want    PluginConfigInterface
+		wantErr bool
+	}{
+		"Wire": {
+			config: `{"interfaceName":"e1-1","type":"wire"}`,
+			want: &WirePlugin{
+				PluginCniType: PluginCniType{Type: WirePluginType},
+				InterfaceName: "e1-1",
+			},
+		},
+		"Macvlan": {
+			config: `{"ipam":{"type":"dhcp"},"master":"eth1","mode":"bridge","type":"macvlan"}`,
+			want: &MacvlanPlugin{
+				PluginCniType: PluginCniType{Type: MacvlanPluginType},
+				Master:        "eth1",
+				Mode:          "bridge",
+				IPAM:          &IPAM{Type: DHCPIPAMType},
+			},
+		},
+		"HostDevice": {
+			config: `{"device":"ens1f1","type":"host-device"}`,
+			want: &HostDevicePlugin{
+				PluginCniType: PluginCniType{Type: HostDevicePluginType},
+				Device:        "ens1f1",
+			},
+		},
+		"Unsupported": {
+			config:  `{"type":"ipvlan"}`,
+			wantErr: true,
+		},
+		"Invalid": {
+			config:  `{`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParsePluginConfig([]byte(tc.config))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetPluginConfigs(t *testing.T) {
+	cases := map[string]struct {
+		config  string
+		want    []PluginConfigInterface
+		wantErr bool
+	}{
+		"Chained": {
+			config: `{"cniVersion":"0.3.1","plugins":[{"interfaceName":"e1-1","mtu":9000,"type":"wire"},{"mac":true,"type":"tuning"}]}`,
+			want: []PluginConfigInterface{
+				&WirePlugin{
+					PluginCniType: PluginCniType{Type: WirePluginType},
+					InterfaceName: "e1-1",
+					MTU:           9000,
+				},
+				&TuningPlugin{
+					PluginCniType: PluginCniType{Type: TuningPluginType},
+					Capabilities:  Capabilities{Mac: true},
+				},
+			},
+		},
+		"Unsupported": {
+			config:  `{"cniVersion":"0.3.1","plugins":[{"type":"ipvlan"}]}`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetPluginConfigs(&nadv1.NetworkAttachmentDefinition{
+				Spec: nadv1.NetworkAttachmentDefinitionSpec{Config: tc.config},
+			})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
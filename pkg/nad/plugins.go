@@ -0,0 +1,162 @@
+package nad
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+)
+
+// Plugins is the registry of the plugin configs keyed by cni type. The providers and
+// the node config select the link implementation of an interface by cni type.
+//
+//nolint:gochecknoglobals
+var Plugins = map[string]func() PluginConfigInterface{
+	WirePluginType:       func() PluginConfigInterface { return &WirePlugin{} },
+	TuningPluginType:     func() PluginConfigInterface { return &TuningPlugin{} },
+	MacvlanPluginType:    func() PluginConfigInterface { return &MacvlanPlugin{} },
+	BridgePluginType:     func() PluginConfigInterface { return &BridgePlugin{} },
+	HostDevicePluginType: func() PluginConfigInterface { return &HostDevicePlugin{} },
+	VxlanPluginType:      func() PluginConfigInterface { return &VxlanPlugin{} },
+	SriovPluginType:      func() PluginConfigInterface { return &SriovPlugin{} },
+}
+
+// GetPluginTypes returns the sorted cni types of the registered plugins.
+func GetPluginTypes() []string {
+	types := make([]string, 0, len(Plugins))
+	for t := range Plugins {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ParsePluginConfig returns the typed config of the plugin of the json config, the
+// plugin is selected by the cni type of the config.
+func ParsePluginConfig(b []byte) (PluginConfigInterface, error) {
+	t := PluginCniType{}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, fmt.Errorf("cannot parse plugin config, err: %w", err)
+	}
+	newPlugin, ok := Plugins[t.Type]
+	if !ok {
+		return nil, fmt.Errorf("cni type %q is not supported, supported types are %q", t.Type, GetPluginTypes())
+	}
+	p := newPlugin()
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("cannot parse %s plugin config, err: %w", t.Type, err)
+	}
+	return p, nil
+}
+
+// GetPluginConfigs returns the typed configs of the plugins of the config of the nad.
+func GetPluginConfigs(nad *nadv1.NetworkAttachmentDefinition) ([]PluginConfigInterface, error) {
+	nadConfig := NadConfig{}
+	if err := json.Unmarshal([]byte(nad.Spec.Config), &nadConfig); err != nil {
+		return nil, fmt.Errorf("cannot parse config of nad %s, err: %w", nad.GetName(), err)
+	}
+	plugins := make([]PluginConfigInterface, 0, len(nadConfig.Plugins))
+	for _, plugin := range nadConfig.Plugins {
+		b, err := json.Marshal(plugin)
+		if err != nil {
+			return nil, err
+		}
+		p, err := ParsePluginConfig(b)
+		if err != nil {
+			return nil, fmt.Errorf("nad %s: %w", nad.GetName(), err)
+		}
+		plugins = append(plugins, p)
+	}
+	return plugins, nil
+}
+
+// MacvlanPlugin attaches a macvlan interface of the master interface of the host.
+type MacvlanPlugin struct {
+	PluginCniType
+	// Master is the interface of the host, the default route interface when empty
+	Master string `json:"master,omitempty"`
+	// Mode is one of bridge, private, vepa or passthru
+	Mode string `json:"mode,omitempty"`
+	MTU  int    `json:"mtu,omitempty"`
+	IPAM *IPAM  `json:"ipam,omitempty"`
+}
+
+// BridgePlugin attaches a veth interface to a linux bridge of the host.
+type BridgePlugin struct {
+	PluginCniType
+	Bridge      string `json:"bridge,omitempty"`
+	IsGateway   bool   `json:"isGateway,omitempty"`
+	IPMasq      bool   `json:"ipMasq,omitempty"`
+	HairpinMode bool   `json:"hairpinMode,omitempty"`
+	PromiscMode bool   `json:"promiscMode,omitempty"`
+	VLAN        int    `json:"vlan,omitempty"`
+	MTU         int    `json:"mtu,omitempty"`
+	IPAM        *IPAM  `json:"ipam,omitempty"`
+}
+
+// HostDevicePlugin moves a device of the host into the container, which passes a
+// physical nic through to the node. The device is selected by one of the fields.
+type HostDevicePlugin struct {
+	PluginCniType
+	Device     string `json:"device,omitempty"`
+	HWAddr     string `json:"hwaddr,omitempty"`
+	KernelPath string `json:"kernelpath,omitempty"`
+	PCIBusID   string `json:"pciBusID,omitempty"`
+	IPAM       *IPAM  `json:"ipam,omitempty"`
+}
+
+// VxlanPlugin attaches a point to point vxlan interface, which connects the interface
+// to the interface of a node on another host.
+type VxlanPlugin struct {
+	PluginCniType
+	InterfaceName string `json:"interfaceName,omitempty"`
+	VNI           int    `json:"vni,omitempty"`
+	// Remote is the ip of the remote vtep
+	Remote string `json:"remote,omitempty"`
+	// Dev is the interface of the host that carries the vxlan traffic
+	Dev     string `json:"dev,omitempty"`
+	DstPort int    `json:"dstPort,omitempty"`
+	MTU     int    `json:"mtu,omitempty"`
+	IPAM    *IPAM  `json:"ipam,omitempty"`
+}
+
+// SriovPlugin attaches a virtual function of a sriov nic, the virtual function is
+// allocated by the sriov device plugin.
+type SriovPlugin struct {
+	PluginCniType
+	DeviceID  string `json:"deviceID,omitempty"`
+	VLAN      int    `json:"vlan,omitempty"`
+	VLANQoS   int    `json:"vlanQoS,omitempty"`
+	MAC       string `json:"mac,omitempty"`
+	SpoofChk  string `json:"spoofchk,omitempty"`
+	Trust     string `json:"trust,omitempty"`
+	LinkState string `json:"link_state,omitempty"`
+	MinTxRate int    `json:"min_tx_rate,omitempty"`
+	MaxTxRate int    `json:"max_tx_rate,omitempty"`
+	IPAM      *IPAM  `json:"ipam,omitempty"`
+}
+
+// IPAM defines the ip address management of the interface of a plugin.
+type IPAM struct {
+	Type string `json:"type,omitempty"`
+	// host-local and whereabouts
+	Subnet     string `json:"subnet,omitempty"`
+	Range      string `json:"range,omitempty"`
+	RangeStart string `json:"rangeStart,omitempty"`
+	RangeEnd   string `json:"rangeEnd,omitempty"`
+	Gateway    string `json:"gateway,omitempty"`
+	// static
+	Addresses []IPAMAddress `json:"addresses,omitempty"`
+	Routes    []IPAMRoute   `json:"routes,omitempty"`
+}
+
+type IPAMAddress struct {
+	Address string `json:"address,omitempty"`
+	Gateway string `json:"gateway,omitempty"`
+}
+
+type IPAMRoute struct {
+	Dst string `json:"dst,omitempty"`
+	GW  string `json:"gw,omitempty"`
+}
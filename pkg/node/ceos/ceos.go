@@ -0,0 +1,596 @@
+package ceos
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/cert"
+	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	"github.com/scrapli/scrapligo/driver/network"
+	"github.com/scrapli/scrapligo/driver/options"
+	"github.com/scrapli/scrapligo/platform"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	AristaCEOSProvider    = "ceos.arista.com"
+	defaultCEOSImageName  = "ceos:latest"
+	defaultCEOSModel      = "ceoslab"
+	scrapliGoCEOSKey      = "arista_eos"
+	defaultInterfaceMTU   = 9214
+	interfacePrefix       = "Ethernet"
+	containerInterfacePfx = "eth"
+
+	//
+	terminationGracePeriodSeconds = 0
+	readinessInitialDelay         = 30
+	readinessPeriodSeconds        = 5
+	readinessFailureThreshold     = 30
+
+	// volumes
+	defaultSecretUserNameKey = "username"
+	defaultSecretPasswordKey = "password"
+	certificateProfileName   = "k8s-profile"
+	certificateDir           = "/persist/secure/ssl/certs"
+	keyDir                   = "/persist/secure/ssl/keys"
+	flashVolName             = "flash"
+	flashMntPath             = "/mnt/flash"
+	startupConfigVolName     = "startup-config"
+	startupConfigVolMntPath  = "/tmp/startup-config"
+	startupConfigInitName    = "startup-config"
+)
+
+var (
+	//nolint:gochecknoglobals
+	defaultCmd = []string{
+		"/sbin/init",
+	}
+
+	// defaultEnv defines the environment variables that run EOS as cEOS lab
+	// with the ethN interfaces of the container as front panel ports and eth0
+	// as the management interface.
+	//nolint:gochecknoglobals
+	defaultEnv = map[string]string{
+		"CEOS":                                "1",
+		"EOS_PLATFORM":                        "ceoslab",
+		"container":                           "docker",
+		"ETBA":                                "1",
+		"SKIP_ZEROTOUCH_BARRIER_IN_SYSDBINIT": "1",
+		"INTFTYPE":                            containerInterfacePfx,
+		"MAPETH0":                             "1",
+		"MGMT_INTF":                           "eth0",
+	}
+
+	//nolint:gochecknoglobals
+	defaultResourceRequests = map[string]string{
+		"cpu":    "1",
+		"memory": "2Gi",
+	}
+	defaultResourceLimits = map[string]string{}
+)
+
+// Register registers the node in the NodeRegistry.
+func Register(r node.NodeRegistry) {
+	r.Register(AristaCEOSProvider, func(c client.Client, s *runtime.Scheme) node.Node {
+		return &ceos{
+			Client: c,
+			scheme: s,
+		}
+	})
+}
+
+type ceos struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+func (r *ceos) GetProviderType(ctx context.Context) node.ProviderType {
+	return node.ProviderTypeNetwork
+}
+
+func (r *ceos) GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec {
+	return &invv1alpha1.NodeConfigSpec{
+		Provider: AristaCEOSProvider,
+		Model:    pointer.String(defaultCEOSModel),
+		Image:    pointer.String(defaultCEOSImageName),
+	}
+}
+
+// ValidateNodeConfig validates that the node model of the model exists, cEOS has
+// no variants since the platform is defined by the image.
+func (r *ceos) ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error {
+	if nc.Spec.Model == nil {
+		return nil
+	}
+	if _, err := r.GetNodeModel(ctx, nc); err != nil {
+		return fmt.Errorf("node model %s/%s of model %q not found, create it or use another model",
+			os.Getenv("POD_NAMESPACE"), getNodeModelName(nc), *nc.Spec.Model)
+	}
+	return nil
+}
+
+func (r *ceos) GetNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
+	// get nodeConfig by merging the provider defaults with the node config layers
+	return node.GetNodeConfig(ctx, r.Client, cr, r.GetNodeConfigDefaults(ctx))
+}
+
+func (r *ceos) GetNodeModelConfig(ctx context.Context, nc *invv1alpha1.NodeConfig) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: invv1alpha1.NodeKindAPIVersion,
+		Kind:       invv1alpha1.NodeModelKind,
+		Name:       getNodeModelName(nc),
+		Namespace:  os.Getenv("POD_NAMESPACE"),
+	}
+}
+
+func (r *ceos) GetNodeModel(ctx context.Context, nc *invv1alpha1.NodeConfig) (*invv1alpha1.NodeModel, error) {
+	nm := &invv1alpha1.NodeModel{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      getNodeModelName(nc),
+		Namespace: os.Getenv("POD_NAMESPACE"),
+	}, nm); err != nil {
+		return nil, err
+	}
+	return nm, nil
+}
+
+// GetNetworkAttachmentDefinitions returns a nad for every interface of the node model,
+// the EthernetN interfaces of the node model are named ethN in the container.
+func (r *ceos) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultCEOSModel), err)
+	}
+	itfces := make([]node.Interface, 0, len(nm.Spec.Interfaces))
+	for _, itfce := range nm.Spec.Interfaces {
+		containerName, err := getContainerInterfaceName(itfce.Name)
+		if err != nil {
+			return nil, err
+		}
+		itfces = append(itfces, node.Interface{
+			Name:          itfce.Name,
+			ContainerName: containerName,
+			MTU:           defaultInterfaceMTU,
+		})
+	}
+	return node.GetNetworkAttachmentDefinitions(cr, nc, itfces, r.scheme)
+}
+
+func (r *ceos) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
+	return node.GetPersistentVolumeClaims(cr, nc, r.scheme)
+}
+
+// GetStartupConfig returns the config map with the startup config of the node rendered
+// from the startup config templates referenced in the node config.
+func (r *ceos) GetStartupConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) (*corev1.ConfigMap, error) {
+	if nc.Spec.StartupConfig == nil {
+		return nil, nil
+	}
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultCEOSModel), err)
+	}
+	return node.GetStartupConfig(ctx, r.Client, cr, nc, nm, r.scheme)
+}
+
+func (r *ceos) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*corev1.Pod, error) {
+	nadAnnotation, err := nad.GetNadAnnotation(nads)
+	if err != nil {
+		return nil, err
+	}
+
+	startupConfig, err := r.GetStartupConfig(ctx, cr, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.GetName(),
+			Namespace: cr.GetNamespace(),
+		},
+		Spec: corev1.PodSpec{
+			InitContainers:                getInitContainers(nc),
+			Containers:                    getContainers(cr.GetName(), nc),
+			TerminationGracePeriodSeconds: pointer.Int64(terminationGracePeriodSeconds),
+			NodeSelector:                  map[string]string{},
+			Affinity:                      node.GetAffinity(cr.GetNamespace()),
+			Volumes:                       getVolumes(cr.GetName(), nc),
+		},
+	}
+
+	// the nad annotation is part of the hash since the networks of a pod
+	// are only attached when the pod is created, the startup config data
+	// is part of the hash since it is only read when the pod starts
+	var startupConfigData map[string]string
+	if startupConfig != nil {
+		startupConfigData = startupConfig.Data
+	}
+	hashString, err := node.GetHash([]any{d.Spec, string(nadAnnotation), startupConfigData})
+	if err != nil {
+		return nil, err
+	}
+	if len(d.GetAnnotations()) == 0 {
+		d.ObjectMeta.Annotations = map[string]string{}
+	}
+	d.ObjectMeta.Annotations[invv1alpha1.RevisionHash] = hashString
+	d.ObjectMeta.Annotations[invv1alpha1.NephioWiringKey] = "true"
+	if os.Getenv("ENABLE_NAD") == "true" {
+		d.ObjectMeta.Annotations[nadv1.NetworkAttachmentAnnot] = string(nadAnnotation)
+	}
+
+	if len(d.GetLabels()) == 0 {
+		d.ObjectMeta.Labels = map[string]string{}
+	}
+	d.ObjectMeta.Labels[invv1alpha1.NephioTopologyKey] = cr.Namespace
+
+	if err := ctrl.SetControllerReference(cr, d, r.scheme); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// SetInitialConfig installs the certificate of the node and enables the gnmi server
+// with tls using the cli, since EOS expects the certificates as files on the device.
+func (r *ceos) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	username, password, err := r.getCredentials(ctx, cr)
+	if err != nil {
+		return err
+	}
+	certData, err := r.getCertData(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	d, err := openDriver(ips[0].IP, username, password)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	for _, cmd := range getCertificateCommands(certData) {
+		resp, err := d.SendCommand(cmd)
+		if err != nil {
+			return err
+		}
+		if resp.Failed != nil {
+			return resp.Failed
+		}
+	}
+
+	mresp, err := d.SendConfigs(getInitialConfig(certData))
+	if err != nil {
+		return err
+	}
+	if mresp.Failed != nil {
+		return mresp.Failed
+	}
+
+	resp, err := d.SendCommand("write memory")
+	if err != nil {
+		return err
+	}
+	return resp.Failed
+}
+
+// GetInitialConfigHash returns the hash of the initial config, which includes the
+// certificate data, such that a rotation of the certificate changes the hash.
+func (r *ceos) GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error) {
+	certData, err := r.getCertData(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+	return node.GetHash([]any{getCertificateCommands(certData), getInitialConfig(certData)})
+}
+
+// GetDeviceInfo reads the software version and the model from the json output of
+// show version.
+func (r *ceos) GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error) {
+	username, password, err := r.getCredentials(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	d, err := openDriver(ips[0].IP, username, password)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	resp, err := d.SendCommand("show version | json")
+	if err != nil {
+		return nil, err
+	}
+	version := struct {
+		Version   string `json:"version"`
+		ModelName string `json:"modelName"`
+	}{}
+	if err := json.Unmarshal([]byte(resp.Result), &version); err != nil {
+		return nil, fmt.Errorf("cannot parse show version, err: %w", err)
+	}
+	return &node.DeviceInfo{
+		SoftwareVersion: version.Version,
+		Chassis:         version.ModelName,
+	}, nil
+}
+
+// Teardown saves the running config of the node before the node is deleted, such that
+// the config is preserved when the flash is mounted on a persistent volume.
+func (r *ceos) Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	username, password, err := r.getCredentials(ctx, cr)
+	if err != nil {
+		return err
+	}
+	d, err := openDriver(ips[0].IP, username, password)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	resp, err := d.SendCommand("write memory")
+	if err != nil {
+		return err
+	}
+	return resp.Failed
+}
+
+// openDriver opens an EOS cli session to the node.
+func openDriver(address, username, password string) (*network.Driver, error) {
+	p, err := platform.NewPlatform(
+		scrapliGoCEOSKey,
+		address,
+		options.WithAuthNoStrictKey(),
+		options.WithAuthUsername(username),
+		options.WithAuthPassword(password),
+	)
+	if err != nil {
+		return nil, err
+	}
+	d, err := p.GetNetworkDriver()
+	if err != nil {
+		return nil, err
+	}
+	d.Channel.TimeoutOps = 5 * time.Second
+	if err := d.Open(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (r *ceos) getCredentials(ctx context.Context, cr *invv1alpha1.Node) (string, string, error) {
+	secret := &corev1.Secret{}
+	// we assume right now the default secret name is equal to the provider
+	// this provider username and password
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: AristaCEOSProvider}, secret); err != nil {
+		return "", "", err
+	}
+	return string(secret.Data[defaultSecretUserNameKey]), string(secret.Data[defaultSecretPasswordKey]), nil
+}
+
+func (r *ceos) getCertData(ctx context.Context, cr *invv1alpha1.Node) (*cert.CertData, error) {
+	certSecret := &corev1.Secret{}
+	// this is used to provide certificate for the gnmi server on the device
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}, certSecret); err != nil {
+		return nil, err
+	}
+	return cert.GetCertificateData(certSecret, certificateProfileName)
+}
+
+// getCertificateCommands returns the commands that write the certificate, the key and
+// the trust anchor of the tls profile to the certificate and key stores of EOS.
+// The pem data is base64 encoded to send it on a single line of the cli.
+func getCertificateCommands(certData *cert.CertData) []string {
+	files := []struct {
+		path string
+		data string
+	}{
+		{path: fmt.Sprintf("%s/%s.crt", certificateDir, certData.ProfileName), data: certData.Cert},
+		{path: fmt.Sprintf("%s/%s-ca.crt", certificateDir, certData.ProfileName), data: certData.CA},
+		{path: fmt.Sprintf("%s/%s.key", keyDir, certData.ProfileName), data: certData.Key},
+	}
+	cmds := make([]string, 0, len(files))
+	for _, f := range files {
+		cmds = append(cmds, fmt.Sprintf("bash timeout 10 sudo sh -c 'echo %s | base64 -d > %s'",
+			base64.StdEncoding.EncodeToString([]byte(f.data)), f.path))
+	}
+	return cmds
+}
+
+// getInitialConfig returns the initial config commands of the node, which enable
+// lldp and the gnmi server with the tls profile of the node.
+func getInitialConfig(certData *cert.CertData) []string {
+	return []string{
+		"lldp run",
+		fmt.Sprintf("security pki ssl profile %s", certData.ProfileName),
+		fmt.Sprintf("certificate %s.crt key %s.key", certData.ProfileName, certData.ProfileName),
+		fmt.Sprintf("trust certificate %s-ca.crt", certData.ProfileName),
+		"exit",
+		"management api gnmi",
+		"transport grpc default",
+		fmt.Sprintf("ssl profile %s", certData.ProfileName),
+		"exit",
+		"provider eos-native",
+		"exit",
+		"management api http-commands",
+		"no shutdown",
+		"exit",
+	}
+}
+
+// getContainerInterfaceName returns the name of the interface in the container,
+// the EthernetN interfaces of EOS map to the ethN interfaces of the container.
+func getContainerInterfaceName(name string) (string, error) {
+	idx, err := strconv.Atoi(strings.TrimPrefix(name, interfacePrefix))
+	if err != nil || !strings.HasPrefix(name, interfacePrefix) || idx < 1 {
+		return "", fmt.Errorf("interface %q of the node model is not a %sN interface", name, interfacePrefix)
+	}
+	return fmt.Sprintf("%s%d", containerInterfacePfx, idx), nil
+}
+
+func getNodeModelName(nc *invv1alpha1.NodeConfig) string {
+	return fmt.Sprintf("%s-%s", AristaCEOSProvider, nc.GetModel(defaultCEOSModel))
+}
+
+func getContainers(name string, nc *invv1alpha1.NodeConfig) []corev1.Container {
+	return []corev1.Container{{
+		Name:            name,
+		Image:           nc.GetImage(defaultCEOSImageName),
+		Command:         defaultCmd,
+		Args:            getArgs(),
+		Env:             getEnv(),
+		Resources:       nc.GetResourceRequirements(defaultResourceRequests, defaultResourceLimits),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &corev1.SecurityContext{
+			Privileged: pointer.Bool(true),
+			RunAsUser:  pointer.Int64(0),
+		},
+		TTY:          true,
+		Stdin:        true,
+		VolumeMounts: getVolumeMounts(nc),
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{
+						"Cli", "-p", "15", "-c", "show version",
+					},
+				},
+			},
+			InitialDelaySeconds: readinessInitialDelay,
+			PeriodSeconds:       readinessPeriodSeconds,
+			FailureThreshold:    readinessFailureThreshold,
+		},
+	}}
+}
+
+// getEnv returns the environment variables of cEOS sorted by name
+// such that the hash of the pod spec is stable.
+func getEnv() []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0, len(defaultEnv))
+	for k, v := range defaultEnv {
+		env = append(env, corev1.EnvVar{Name: k, Value: v})
+	}
+	sort.Slice(env, func(i, j int) bool {
+		return env[i].Name < env[j].Name
+	})
+	return env
+}
+
+// getArgs returns the arguments of /sbin/init, the environment variables are also
+// passed to systemd since the EOS agents do not inherit the environment of init.
+func getArgs() []string {
+	env := getEnv()
+	args := make([]string, 0, len(env))
+	for _, e := range env {
+		args = append(args, fmt.Sprintf("systemd.setenv=%s=%s", e.Name, e.Value))
+	}
+	return args
+}
+
+func getVolumes(name string, nc *invv1alpha1.NodeConfig) []corev1.Volume {
+	vols := []corev1.Volume{}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vols = append(vols, corev1.Volume{
+			Name: pv.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: node.GetPersistentVolumeClaimName(name, pv.Name),
+				},
+			},
+		})
+	}
+	if nc.Spec.StartupConfig != nil {
+		vols = append(vols, corev1.Volume{
+			Name: startupConfigVolName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: node.GetStartupConfigMapName(name),
+					},
+				},
+			},
+		})
+		if !hasPersistentFlash(nc) {
+			vols = append(vols, corev1.Volume{
+				Name:         flashVolName,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			})
+		}
+	}
+	return vols
+}
+
+func getVolumeMounts(nc *invv1alpha1.NodeConfig) []corev1.VolumeMount {
+	vms := []corev1.VolumeMount{}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vms = append(vms, corev1.VolumeMount{
+			Name:      pv.Name,
+			MountPath: pv.MountPath,
+		})
+	}
+	if nc.Spec.StartupConfig != nil && !hasPersistentFlash(nc) {
+		vms = append(vms, corev1.VolumeMount{
+			Name:      flashVolName,
+			MountPath: flashMntPath,
+		})
+	}
+	return vms
+}
+
+// getInitContainers returns an init container that copies the startup config
+// to the flash of the node, since EOS saves the config to the same file
+// the startup config cannot be mounted read only from the config map.
+func getInitContainers(nc *invv1alpha1.NodeConfig) []corev1.Container {
+	if nc.Spec.StartupConfig == nil {
+		return nil
+	}
+	flashVolMnt := corev1.VolumeMount{Name: flashVolName, MountPath: flashMntPath}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		if pv.MountPath == flashMntPath {
+			flashVolMnt = corev1.VolumeMount{Name: pv.Name, MountPath: pv.MountPath}
+		}
+	}
+	return []corev1.Container{{
+		Name:            startupConfigInitName,
+		Image:           nc.GetImage(defaultCEOSImageName),
+		Command:         []string{"sh", "-c"},
+		Args:            []string{fmt.Sprintf("cp -L %s/* %s/", startupConfigVolMntPath, flashMntPath)},
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser: pointer.Int64(0),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      startupConfigVolName,
+				MountPath: startupConfigVolMntPath,
+				ReadOnly:  true,
+			},
+			flashVolMnt,
+		},
+	}}
+}
+
+// hasPersistentFlash returns true when a persistent volume is mounted on the flash
+// of the node.
+func hasPersistentFlash(nc *invv1alpha1.NodeConfig) bool {
+	for _, pv := range nc.Spec.PersistentVolumes {
+		if pv.MountPath == flashMntPath {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package ceos
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/henderiw-nephio/network-node-operator/pkg/cert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGetContainerInterfaceName(t *testing.T) {
+	cases := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"First": {
+			input: "Ethernet1",
+			want:  "eth1",
+		},
+		"MultiDigit": {
+			input: "Ethernet48",
+			want:  "eth48",
+		},
+		"Zero": {
+			input:   "Ethernet0",
+			wantErr: true,
+		},
+		"NoIndex": {
+			input:   "Ethernet",
+			wantErr: true,
+		},
+		"Module": {
+			input:   "Ethernet1/1",
+			wantErr: true,
+		},
+		"OtherScheme": {
+			input:   "e1-1",
+			wantErr: true,
+		},
+		"LowerCase": {
+			input:   "ethernet1",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := getContainerInterfaceName(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("want error, got: %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetEnv(t *testing.T) {
+	want := []corev1.EnvVar{
+		{Name: "CEOS", Value: "1"},
+		{Name: "EOS_PLATFORM", Value: "ceoslab"},
+		{Name: "ETBA", Value: "1"},
+		{Name: "INTFTYPE", Value: "eth"},
+		{Name: "MAPETH0", Value: "1"},
+		{Name: "MGMT_INTF", Value: "eth0"},
+		{Name: "SKIP_ZEROTOUCH_BARRIER_IN_SYSDBINIT", Value: "1"},
+		{Name: "container", Value: "docker"},
+	}
+	if diff := cmp.Diff(want, getEnv()); diff != "" {
+		t.Errorf("-want, +got:\n%s", diff)
+	}
+}
+
+func TestGetArgs(t *testing.T) {
+	want := []string{
+		"systemd.setenv=CEOS=1",
+		"systemd.setenv=EOS_PLATFORM=ceoslab",
+		"systemd.setenv=ETBA=1",
+		"systemd.setenv=INTFTYPE=eth",
+		"systemd.setenv=MAPETH0=1",
+		"systemd.setenv=MGMT_INTF=eth0",
+		"systemd.setenv=SKIP_ZEROTOUCH_BARRIER_IN_SYSDBINIT=1",
+		"systemd.setenv=container=docker",
+	}
+	if diff := cmp.Diff(want, getArgs()); diff != "" {
+		t.Errorf("-want, +got:\n%s", diff)
+	}
+}
+
+func TestGetCertificateCommands(t *testing.T) {
+	certData := &cert.CertData{
+		ProfileName: certificateProfileName,
+		CA:          "ca",
+		Cert:        "cert",
+		Key:         "key",
+	}
+	// want is the data that is written to every path
+	want := map[string]string{
+		certificateDir + "/k8s-profile.crt":    "cert",
+		certificateDir + "/k8s-profile-ca.crt": "ca",
+		keyDir + "/k8s-profile.key":            "key",
+	}
+
+	got := map[string]string{}
+	for _, cmd := range getCertificateCommands(certData) {
+		// bash timeout 10 sudo sh -c 'echo <data> | base64 -d > <path>'
+		fields := strings.Fields(strings.TrimSuffix(cmd, "'"))
+		if len(fields) != 13 || fields[6] != "'echo" {
+			t.Fatalf("unexpected command: %s", cmd)
+		}
+		b, err := base64.StdEncoding.DecodeString(fields[7])
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[fields[12]] = string(b)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("-want, +got:\n%s", diff)
+	}
+}
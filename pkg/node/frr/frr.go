@@ -0,0 +1,466 @@
+package frr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	FRRProvider           = "frr.frrouting.org"
+	defaultFRRImageName   = "quay.io/frrouting/frr:9.0.1"
+	defaultFRRModel       = "default"
+	defaultInterfaceMTU   = 9000
+	containerInterfacePfx = "eth"
+
+	// node labels that define the bgp config of the default frr.conf
+	asnLabelKey      = FRRProvider + "/asn"
+	routerIDLabelKey = FRRProvider + "/router-id"
+
+	//
+	terminationGracePeriodSeconds = 0
+	readinessInitialDelay         = 2
+	readinessPeriodSeconds        = 5
+	readinessFailureThreshold     = 10
+
+	// volumes
+	startupConfigVolName = "startup-config"
+	daemonsKey           = "daemons"
+	daemonsMntPath       = "/etc/frr/daemons"
+	frrConfKey           = "frr.conf"
+	frrConfMntPath       = "/etc/frr/frr.conf"
+)
+
+var (
+	//nolint:gochecknoglobals
+	defaultCmd = []string{
+		"/sbin/tini",
+		"--",
+	}
+
+	//nolint:gochecknoglobals
+	defaultArgs = []string{
+		"/usr/lib/frr/docker-start",
+	}
+
+	//nolint:gochecknoglobals
+	defaultResourceRequests = map[string]string{
+		"cpu":    "50m",
+		"memory": "64Mi",
+	}
+	defaultResourceLimits = map[string]string{}
+
+	//nolint:gochecknoglobals
+	daemonsTemplate = template.Must(template.New(daemonsKey).Parse(`bgpd={{ if .ASN }}yes{{ else }}no{{ end }}
+ospfd=no
+ospf6d=no
+ripd=no
+ripngd=no
+isisd=no
+pimd=no
+ldpd=no
+nhrpd=no
+eigrpd=no
+babeld=no
+sharpd=no
+pbrd=no
+bfdd={{ if .ASN }}yes{{ else }}no{{ end }}
+fabricd=no
+vrrpd=no
+pathd=no
+
+vtysh_enable=yes
+zebra_options="  -A 127.0.0.1 -s 90000000"
+bgpd_options="   -A 127.0.0.1"
+staticd_options="-A 127.0.0.1"
+bfdd_options="   -A 127.0.0.1"
+`))
+
+	// frrConfTemplate peers with the neighbor on every wired interface using bgp
+	// unnumbered when the node is labeled with an asn
+	//nolint:gochecknoglobals
+	frrConfTemplate = template.Must(template.New(frrConfKey).Parse(`frr defaults datacenter
+hostname {{ .Name }}
+service integrated-vtysh-config
+!
+{{- range .Interfaces }}
+interface {{ . }}
+ no shutdown
+!
+{{- end }}
+{{- if .ASN }}
+router bgp {{ .ASN }}
+{{- if .RouterID }}
+ bgp router-id {{ .RouterID }}
+{{- end }}
+ no bgp ebgp-requires-policy
+{{- range .Interfaces }}
+ neighbor {{ . }} interface remote-as external
+{{- end }}
+ !
+ address-family ipv4 unicast
+  redistribute connected
+ exit-address-family
+ !
+ address-family ipv6 unicast
+  redistribute connected
+{{- range .Interfaces }}
+  neighbor {{ . }} activate
+{{- end }}
+ exit-address-family
+!
+{{- end }}
+line vty
+!
+`))
+)
+
+// configInput is the data of the default daemons and frr.conf templates.
+type configInput struct {
+	// Name of the node
+	Name string
+	// ASN of the node, when empty bgp is not enabled
+	ASN string
+	// RouterID of the node, when empty the router id is selected by frr
+	RouterID string
+	// Interfaces are the names of the wired interfaces in the container
+	Interfaces []string
+}
+
+// Register registers the node in the NodeRegistry.
+func Register(r node.NodeRegistry) {
+	r.Register(FRRProvider, func(c client.Client, s *runtime.Scheme) node.Node {
+		return &frr{
+			Client: c,
+			scheme: s,
+		}
+	})
+}
+
+type frr struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+func (r *frr) GetProviderType(ctx context.Context) node.ProviderType {
+	return node.ProviderTypeNetwork
+}
+
+func (r *frr) GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec {
+	return &invv1alpha1.NodeConfigSpec{
+		Provider: FRRProvider,
+		Model:    pointer.String(defaultFRRModel),
+		Image:    pointer.String(defaultFRRImageName),
+	}
+}
+
+// ValidateNodeConfig validates that the node model of the model exists, since frr
+// has no variants.
+func (r *frr) ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error {
+	if nc.Spec.Model == nil {
+		return nil
+	}
+	if _, err := r.GetNodeModel(ctx, nc); err != nil {
+		return fmt.Errorf("node model %s/%s of model %q not found, create it or use another model",
+			os.Getenv("POD_NAMESPACE"), getNodeModelName(nc), *nc.Spec.Model)
+	}
+	return nil
+}
+
+func (r *frr) GetNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
+	// get nodeConfig by merging the provider defaults with the node config layers
+	return node.GetNodeConfig(ctx, r.Client, cr, r.GetNodeConfigDefaults(ctx))
+}
+
+func (r *frr) GetNodeModelConfig(ctx context.Context, nc *invv1alpha1.NodeConfig) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: invv1alpha1.NodeKindAPIVersion,
+		Kind:       invv1alpha1.NodeModelKind,
+		Name:       getNodeModelName(nc),
+		Namespace:  os.Getenv("POD_NAMESPACE"),
+	}
+}
+
+func (r *frr) GetNodeModel(ctx context.Context, nc *invv1alpha1.NodeConfig) (*invv1alpha1.NodeModel, error) {
+	nm := &invv1alpha1.NodeModel{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      getNodeModelName(nc),
+		Namespace: os.Getenv("POD_NAMESPACE"),
+	}, nm); err != nil {
+		return nil, err
+	}
+	return nm, nil
+}
+
+// GetNetworkAttachmentDefinitions returns a nad for every interface of the node model,
+// the interfaces are named ethN in the container in the order of the node model.
+func (r *frr) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultFRRModel), err)
+	}
+	itfces := make([]node.Interface, 0, len(nm.Spec.Interfaces))
+	for i, itfce := range nm.Spec.Interfaces {
+		itfces = append(itfces, node.Interface{
+			Name:          itfce.Name,
+			ContainerName: getContainerInterfaceName(i),
+			MTU:           defaultInterfaceMTU,
+		})
+	}
+	return node.GetNetworkAttachmentDefinitions(cr, nc, itfces, r.scheme)
+}
+
+func (r *frr) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
+	return node.GetPersistentVolumeClaims(cr, nc, r.scheme)
+}
+
+// GetStartupConfig returns the config map with the daemons and the frr.conf of the node.
+// The startup config templates referenced in the node config take precedence, the files
+// they don't provide are rendered from the wired interfaces of the node model and the
+// labels of the node. An interface is wired when it has a nad, i.e. when it is connected
+// through a link or through the cni plugin that the node config selects.
+func (r *frr) GetStartupConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) (*corev1.ConfigMap, error) {
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultFRRModel), err)
+	}
+	data := map[string]string{}
+	if nc.Spec.StartupConfig != nil {
+		cm, err := node.GetStartupConfig(ctx, r.Client, cr, nc, nm, r.scheme)
+		if err != nil {
+			return nil, err
+		}
+		data = cm.Data
+	}
+
+	links, err := node.GetLinks(ctx, r.Client, cr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list links, err: %w", err)
+	}
+	plugins, err := node.GetInterfacePlugins(nc)
+	if err != nil {
+		return nil, err
+	}
+	wired := map[string]bool{}
+	for itfceName := range links {
+		wired[itfceName] = true
+	}
+	for itfceName := range plugins {
+		wired[itfceName] = true
+	}
+
+	input := getConfigInput(cr, nm, wired)
+	for key, t := range map[string]*template.Template{
+		daemonsKey: daemonsTemplate,
+		frrConfKey: frrConfTemplate,
+	} {
+		if _, ok := data[key]; ok {
+			continue
+		}
+		buf := new(bytes.Buffer)
+		if err := t.Execute(buf, input); err != nil {
+			return nil, fmt.Errorf("cannot render %s, err: %w", key, err)
+		}
+		data[key] = buf.String()
+	}
+	return node.NewStartupConfigMap(cr, data, r.scheme)
+}
+
+func (r *frr) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*corev1.Pod, error) {
+	nadAnnotation, err := nad.GetNadAnnotation(nads)
+	if err != nil {
+		return nil, err
+	}
+
+	startupConfig, err := r.GetStartupConfig(ctx, cr, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.GetName(),
+			Namespace: cr.GetNamespace(),
+		},
+		Spec: corev1.PodSpec{
+			Containers:                    getContainers(cr.GetName(), nc),
+			TerminationGracePeriodSeconds: pointer.Int64(terminationGracePeriodSeconds),
+			NodeSelector:                  map[string]string{},
+			Affinity:                      node.GetAffinity(cr.GetNamespace()),
+			Volumes:                       getVolumes(cr.GetName(), nc),
+		},
+	}
+
+	// the nad annotation is part of the hash since the networks of a pod
+	// are only attached when the pod is created, the startup config data
+	// is part of the hash since it is only read when the pod starts
+	hashString, err := node.GetHash([]any{d.Spec, string(nadAnnotation), startupConfig.Data})
+	if err != nil {
+		return nil, err
+	}
+	if len(d.GetAnnotations()) == 0 {
+		d.ObjectMeta.Annotations = map[string]string{}
+	}
+	d.ObjectMeta.Annotations[invv1alpha1.RevisionHash] = hashString
+	d.ObjectMeta.Annotations[invv1alpha1.NephioWiringKey] = "true"
+	if os.Getenv("ENABLE_NAD") == "true" {
+		d.ObjectMeta.Annotations[nadv1.NetworkAttachmentAnnot] = string(nadAnnotation)
+	}
+
+	if len(d.GetLabels()) == 0 {
+		d.ObjectMeta.Labels = map[string]string{}
+	}
+	d.ObjectMeta.Labels[invv1alpha1.NephioTopologyKey] = cr.Namespace
+
+	if err := ctrl.SetControllerReference(cr, d, r.scheme); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// SetInitialConfig is not applicable, the config of the node is delivered through
+// the frr.conf of the startup config.
+func (r *frr) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	return nil
+}
+
+// GetInitialConfigHash returns an empty hash since the node has no initial config.
+func (r *frr) GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error) {
+	return "", nil
+}
+
+// GetDeviceInfo returns no device information since the node is not bootstrapped.
+func (r *frr) GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error) {
+	return nil, nil
+}
+
+// Teardown is not applicable, the pod and its resources are deleted by the controller.
+func (r *frr) Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	return nil
+}
+
+func getNodeModelName(nc *invv1alpha1.NodeConfig) string {
+	return fmt.Sprintf("%s-%s", FRRProvider, nc.GetModel(defaultFRRModel))
+}
+
+// getContainerInterfaceName returns the name of the interface in the container,
+// eth0 is the pod interface so the interfaces of the node model start at eth1.
+func getContainerInterfaceName(idx int) string {
+	return fmt.Sprintf("%s%d", containerInterfacePfx, idx+1)
+}
+
+// getConfigInput returns the input of the default templates from the wired interfaces
+// of the node model and the labels of the node. The container names of the interfaces
+// keep the index of the interface in the node model, as the nads do.
+func getConfigInput(cr *invv1alpha1.Node, nm *invv1alpha1.NodeModel, wired map[string]bool) *configInput {
+	labels := node.GetNodeLabels(cr)
+	input := &configInput{
+		Name:       cr.GetName(),
+		ASN:        labels[asnLabelKey],
+		RouterID:   labels[routerIDLabelKey],
+		Interfaces: make([]string, 0, len(wired)),
+	}
+	for i, itfce := range nm.Spec.Interfaces {
+		if wired[itfce.Name] {
+			input.Interfaces = append(input.Interfaces, getContainerInterfaceName(i))
+		}
+	}
+	return input
+}
+
+func getContainers(name string, nc *invv1alpha1.NodeConfig) []corev1.Container {
+	return []corev1.Container{{
+		Name:            name,
+		Image:           nc.GetImage(defaultFRRImageName),
+		Command:         defaultCmd,
+		Args:            defaultArgs,
+		Resources:       nc.GetResourceRequirements(defaultResourceRequests, defaultResourceLimits),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{
+				Add: []corev1.Capability{"NET_ADMIN", "NET_RAW", "SYS_ADMIN"},
+			},
+		},
+		VolumeMounts: getVolumeMounts(nc),
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{
+						"vtysh",
+						"-c",
+						"show version",
+					},
+				},
+			},
+			InitialDelaySeconds: readinessInitialDelay,
+			PeriodSeconds:       readinessPeriodSeconds,
+			FailureThreshold:    readinessFailureThreshold,
+		},
+	}}
+}
+
+func getVolumes(name string, nc *invv1alpha1.NodeConfig) []corev1.Volume {
+	vols := []corev1.Volume{
+		{
+			Name: startupConfigVolName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: node.GetStartupConfigMapName(name),
+					},
+				},
+			},
+		},
+	}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vols = append(vols, corev1.Volume{
+			Name: pv.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: node.GetPersistentVolumeClaimName(name, pv.Name),
+				},
+			},
+		})
+	}
+	return vols
+}
+
+// getVolumeMounts mounts the daemons and the frr.conf of the startup config as files,
+// such that the other files in /etc/frr of the image are preserved.
+func getVolumeMounts(nc *invv1alpha1.NodeConfig) []corev1.VolumeMount {
+	vms := []corev1.VolumeMount{
+		{
+			Name:      startupConfigVolName,
+			MountPath: daemonsMntPath,
+			SubPath:   daemonsKey,
+			ReadOnly:  true,
+		},
+		{
+			Name:      startupConfigVolName,
+			MountPath: frrConfMntPath,
+			SubPath:   frrConfKey,
+			ReadOnly:  true,
+		},
+	}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vms = append(vms, corev1.VolumeMount{
+			Name:      pv.Name,
+			MountPath: pv.MountPath,
+		})
+	}
+	return vms
+}
@@ -0,0 +1,189 @@
+package frr
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testPodNamespace = "network-system"
+
+func getTestLink(name, nodeName, itfceName string) *invv1alpha1.Link {
+	return &invv1alpha1.Link{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: invv1alpha1.LinkSpec{
+			Endpoints: []invv1alpha1.LinkEndpoint{
+				{NodeName: nodeName, InterfaceName: itfceName},
+				{NodeName: "peer", InterfaceName: itfceName},
+			},
+		},
+	}
+}
+
+func TestGetStartupConfig(t *testing.T) {
+	cases := map[string]struct {
+		labels      map[string]string
+		links       []client.Object
+		annotations map[string]string
+		wantDaemons string
+		wantFrrConf string
+	}{
+		"Bgp": {
+			labels: map[string]string{
+				asnLabelKey:      "65001",
+				routerIDLabelKey: "10.0.0.1",
+			},
+			links: []client.Object{
+				getTestLink("link1", "node1", "e1-1"),
+				getTestLink("link3", "node1", "e1-3"),
+				getTestLink("other", "node2", "e1-2"),
+			},
+			wantDaemons: "bgpd=yes",
+			wantFrrConf: `frr defaults datacenter
+hostname node1
+service integrated-vtysh-config
+!
+interface eth1
+ no shutdown
+!
+interface eth3
+ no shutdown
+!
+router bgp 65001
+ bgp router-id 10.0.0.1
+ no bgp ebgp-requires-policy
+ neighbor eth1 interface remote-as external
+ neighbor eth3 interface remote-as external
+ !
+ address-family ipv4 unicast
+  redistribute connected
+ exit-address-family
+ !
+ address-family ipv6 unicast
+  redistribute connected
+  neighbor eth1 activate
+  neighbor eth3 activate
+ exit-address-family
+!
+line vty
+!
+`,
+		},
+		"NoRouterID": {
+			// the interface that the node config connects through another cni plugin
+			// is wired without a link
+			labels: map[string]string{
+				asnLabelKey: "65001",
+			},
+			annotations: map[string]string{
+				node.InterfacePluginsKey: `{"e1-2":{"type":"macvlan","master":"eth1"}}`,
+			},
+			wantDaemons: "bgpd=yes",
+			wantFrrConf: `frr defaults datacenter
+hostname node1
+service integrated-vtysh-config
+!
+interface eth2
+ no shutdown
+!
+router bgp 65001
+ no bgp ebgp-requires-policy
+ neighbor eth2 interface remote-as external
+ !
+ address-family ipv4 unicast
+  redistribute connected
+ exit-address-family
+ !
+ address-family ipv6 unicast
+  redistribute connected
+  neighbor eth2 activate
+ exit-address-family
+!
+line vty
+!
+`,
+		},
+		"NoASN": {
+			links: []client.Object{
+				getTestLink("link1", "node1", "e1-1"),
+			},
+			wantDaemons: "bgpd=no",
+			wantFrrConf: `frr defaults datacenter
+hostname node1
+service integrated-vtysh-config
+!
+interface eth1
+ no shutdown
+!
+line vty
+!
+`,
+		},
+		"NoLinks": {
+			labels: map[string]string{
+				routerIDLabelKey: "10.0.0.1",
+			},
+			wantDaemons: "bgpd=no",
+			wantFrrConf: `frr defaults datacenter
+hostname node1
+service integrated-vtysh-config
+!
+line vty
+!
+`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("POD_NAMESPACE", testPodNamespace)
+			s := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			if err := invv1alpha1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			nm := &invv1alpha1.NodeModel{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testPodNamespace, Name: FRRProvider + "-" + defaultFRRModel},
+				Spec: invv1alpha1.NodeModelSpec{
+					Interfaces: []invv1alpha1.NodeModelInterface{{Name: "e1-1"}, {Name: "e1-2"}, {Name: "e1-3"}},
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(s).WithObjects(append(tc.links, nm)...).Build()
+			r := &frr{Client: c, scheme: s}
+
+			cr := &invv1alpha1.Node{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1", Labels: tc.labels},
+				Spec:       invv1alpha1.NodeSpec{Provider: FRRProvider},
+			}
+			nc := &invv1alpha1.NodeConfig{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+
+			got, err := r.GetStartupConfig(context.Background(), cr, nc)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantDaemons, getFirstLine(got, daemonsKey)); diff != "" {
+				t.Errorf("daemons -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantFrrConf, got.Data[frrConfKey]); diff != "" {
+				t.Errorf("frr.conf -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// getFirstLine returns the first line of the data of the key, which enables bgpd
+func getFirstLine(cm *corev1.ConfigMap, key string) string {
+	return strings.SplitN(cm.Data[key], "\n", 2)[0]
+}
@@ -0,0 +1,372 @@
+package generic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"text/template"
+
+	nodev1alpha1 "github.com/henderiw-nephio/network-node-operator/apis/node/v1alpha1"
+	"github.com/henderiw-nephio/network-node-operator/pkg/bootstrap"
+	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	terminationGracePeriodSeconds = 0
+	startupConfigVolName          = "startup-config"
+	defaultStartupConfigPath      = "/etc/startup-config"
+)
+
+// Register registers the node provider in the NodeRegistry. The nodes of the provider
+// are served by the generic node, which builds the pod of the nodes from the spec of
+// the node provider. The rest config is used to execute the bootstrap command.
+func Register(r node.NodeRegistry, np *nodev1alpha1.NodeProvider, cfg *rest.Config) {
+	np = np.DeepCopy()
+	r.Register(np.GetName(), func(c client.Client, s *runtime.Scheme) node.Node {
+		return &generic{
+			Client:   c,
+			scheme:   s,
+			config:   cfg,
+			provider: np,
+		}
+	})
+}
+
+type generic struct {
+	client.Client
+	scheme   *runtime.Scheme
+	config   *rest.Config
+	provider *nodev1alpha1.NodeProvider
+}
+
+func (r *generic) GetProviderType(ctx context.Context) node.ProviderType {
+	if r.provider.Spec.Type == string(node.ProviderTypeServer) {
+		return node.ProviderTypeServer
+	}
+	return node.ProviderTypeNetwork
+}
+
+func (r *generic) GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec {
+	defaults := &invv1alpha1.NodeConfigSpec{
+		Provider: r.provider.GetName(),
+		Image:    pointer.String(r.provider.Spec.Image),
+	}
+	if r.provider.Spec.Model != "" {
+		defaults.Model = pointer.String(r.provider.Spec.Model)
+	}
+	return defaults
+}
+
+// ValidateNodeConfig validates that the node model of the model exists, since the
+// node provider has no variants.
+func (r *generic) ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error {
+	if nc.Spec.Model == nil {
+		return nil
+	}
+	if _, err := r.GetNodeModel(ctx, nc); err != nil {
+		return fmt.Errorf("node model %s/%s of model %q not found, create it or use another model",
+			os.Getenv("POD_NAMESPACE"), r.getNodeModelName(nc), *nc.Spec.Model)
+	}
+	return nil
+}
+
+func (r *generic) GetNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
+	// get nodeConfig by merging the provider defaults with the node config layers
+	return node.GetNodeConfig(ctx, r.Client, cr, r.GetNodeConfigDefaults(ctx))
+}
+
+func (r *generic) GetNodeModelConfig(ctx context.Context, nc *invv1alpha1.NodeConfig) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: invv1alpha1.NodeKindAPIVersion,
+		Kind:       invv1alpha1.NodeModelKind,
+		Name:       r.getNodeModelName(nc),
+		Namespace:  os.Getenv("POD_NAMESPACE"),
+	}
+}
+
+func (r *generic) GetNodeModel(ctx context.Context, nc *invv1alpha1.NodeConfig) (*invv1alpha1.NodeModel, error) {
+	nm := &invv1alpha1.NodeModel{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      r.getNodeModelName(nc),
+		Namespace: os.Getenv("POD_NAMESPACE"),
+	}, nm); err != nil {
+		return nil, err
+	}
+	return nm, nil
+}
+
+// GetNetworkAttachmentDefinitions returns a nad for every interface of the node model,
+// the interfaces are named in the container using the interface naming of the provider.
+func (r *generic) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model %s, err: %w", r.getNodeModelName(nc), err)
+	}
+	naming := r.provider.Spec.Interfaces
+	if naming == nil {
+		naming = &nodev1alpha1.InterfaceNaming{}
+	}
+	var t *template.Template
+	if naming.Template != "" {
+		t, err = template.New("interface").Option("missingkey=error").Parse(naming.Template)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse interface template of provider %s, err: %w", r.provider.GetName(), err)
+		}
+	}
+	itfces := make([]node.Interface, 0, len(nm.Spec.Interfaces))
+	for i, itfce := range nm.Spec.Interfaces {
+		ni := node.Interface{
+			Name: itfce.Name,
+			MTU:  naming.MTU,
+		}
+		if t != nil {
+			buf := new(bytes.Buffer)
+			if err := t.Execute(buf, map[string]any{"Name": itfce.Name, "Index": i + naming.Offset}); err != nil {
+				return nil, fmt.Errorf("cannot render interface name of %s, err: %w", itfce.Name, err)
+			}
+			ni.ContainerName = buf.String()
+		}
+		itfces = append(itfces, ni)
+	}
+	return node.GetNetworkAttachmentDefinitions(cr, nc, itfces, r.scheme)
+}
+
+func (r *generic) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
+	return node.GetPersistentVolumeClaims(cr, nc, r.scheme)
+}
+
+// GetStartupConfig returns the config map with the startup config of the node rendered
+// from the startup config templates referenced in the node config.
+func (r *generic) GetStartupConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) (*corev1.ConfigMap, error) {
+	if nc.Spec.StartupConfig == nil {
+		return nil, nil
+	}
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model %s, err: %w", r.getNodeModelName(nc), err)
+	}
+	return node.GetStartupConfig(ctx, r.Client, cr, nc, nm, r.scheme)
+}
+
+func (r *generic) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*corev1.Pod, error) {
+	nadAnnotation, err := nad.GetNadAnnotation(nads)
+	if err != nil {
+		return nil, err
+	}
+
+	startupConfig, err := r.GetStartupConfig(ctx, cr, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.GetName(),
+			Namespace: cr.GetNamespace(),
+		},
+		Spec: corev1.PodSpec{
+			Containers:                    r.getContainers(cr.GetName(), nc),
+			TerminationGracePeriodSeconds: pointer.Int64(terminationGracePeriodSeconds),
+			NodeSelector:                  map[string]string{},
+			Affinity:                      node.GetAffinity(cr.GetNamespace()),
+			Volumes:                       r.getVolumes(cr.GetName(), nc),
+		},
+	}
+
+	// the nad annotation is part of the hash since the networks of a pod
+	// are only attached when the pod is created, the startup config data
+	// is part of the hash since it is only read when the pod starts
+	var startupConfigData map[string]string
+	if startupConfig != nil {
+		startupConfigData = startupConfig.Data
+	}
+	hashString, err := node.GetHash([]any{d.Spec, string(nadAnnotation), startupConfigData})
+	if err != nil {
+		return nil, err
+	}
+	if len(d.GetAnnotations()) == 0 {
+		d.ObjectMeta.Annotations = map[string]string{}
+	}
+	d.ObjectMeta.Annotations[invv1alpha1.RevisionHash] = hashString
+	d.ObjectMeta.Annotations[invv1alpha1.NephioWiringKey] = "true"
+	if os.Getenv("ENABLE_NAD") == "true" {
+		d.ObjectMeta.Annotations[nadv1.NetworkAttachmentAnnot] = string(nadAnnotation)
+	}
+
+	if len(d.GetLabels()) == 0 {
+		d.ObjectMeta.Labels = map[string]string{}
+	}
+	d.ObjectMeta.Labels[invv1alpha1.NephioTopologyKey] = cr.Namespace
+
+	if err := ctrl.SetControllerReference(cr, d, r.scheme); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// SetInitialConfig executes the bootstrap command of the provider in the container
+// of the node. Without a bootstrap command the node requires no initial config.
+func (r *generic) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	if r.provider.Spec.Bootstrap == nil {
+		return nil
+	}
+	cmd, err := r.getBootstrapCommand(ctx, cr)
+	if err != nil {
+		return err
+	}
+	_, err = bootstrap.Exec(ctx, bootstrap.ExecConfig{
+		Config:    r.config,
+		Namespace: cr.GetNamespace(),
+		Pod:       cr.GetName(),
+		Container: cr.GetName(),
+	}, cmd)
+	return err
+}
+
+// GetInitialConfigHash returns the hash of the rendered bootstrap command, such that
+// a change of the command or of the node data in the command reapplies the command.
+func (r *generic) GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error) {
+	if r.provider.Spec.Bootstrap == nil {
+		return "", nil
+	}
+	cmd, err := r.getBootstrapCommand(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+	return node.GetHash(cmd)
+}
+
+// GetDeviceInfo returns no device information since the node provider does not
+// define how to retrieve it.
+func (r *generic) GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error) {
+	return nil, nil
+}
+
+// Teardown is not applicable, the pod and its resources are deleted by the controller.
+func (r *generic) Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	return nil
+}
+
+func (r *generic) getNodeModelName(nc *invv1alpha1.NodeConfig) string {
+	return fmt.Sprintf("%s-%s", r.provider.GetName(), nc.GetModel(r.provider.Spec.Model))
+}
+
+// getBootstrapCommand renders the bootstrap command templates with the startup config
+// input of the node.
+func (r *generic) getBootstrapCommand(ctx context.Context, cr *invv1alpha1.Node) ([]string, error) {
+	nc, err := r.GetNodeConfig(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model %s, err: %w", r.getNodeModelName(nc), err)
+	}
+	input, err := node.GetStartupConfigInput(ctx, r.Client, cr, nc, nm)
+	if err != nil {
+		return nil, err
+	}
+	cmd := make([]string, 0, len(r.provider.Spec.Bootstrap.Command))
+	for i, arg := range r.provider.Spec.Bootstrap.Command {
+		t, err := template.New(fmt.Sprintf("bootstrap-%d", i)).Option("missingkey=error").Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse bootstrap command of provider %s, err: %w", r.provider.GetName(), err)
+		}
+		buf := new(bytes.Buffer)
+		if err := t.Execute(buf, input); err != nil {
+			return nil, fmt.Errorf("cannot render bootstrap command of provider %s, err: %w", r.provider.GetName(), err)
+		}
+		cmd = append(cmd, buf.String())
+	}
+	return cmd, nil
+}
+
+func (r *generic) getContainers(name string, nc *invv1alpha1.NodeConfig) []corev1.Container {
+	spec := r.provider.Spec
+	volumeMounts := append([]corev1.VolumeMount{}, spec.VolumeMounts...)
+	for _, pv := range nc.Spec.PersistentVolumes {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      pv.Name,
+			MountPath: pv.MountPath,
+		})
+	}
+	if nc.Spec.StartupConfig != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      startupConfigVolName,
+			MountPath: r.getStartupConfigPath(),
+			ReadOnly:  true,
+		})
+	}
+
+	return []corev1.Container{{
+		Name:            name,
+		Image:           nc.GetImage(spec.Image),
+		Command:         spec.Command,
+		Args:            spec.Args,
+		Env:             spec.Env,
+		Resources:       nc.GetResourceRequirements(getResourceMap(spec.Resources.Requests), getResourceMap(spec.Resources.Limits)),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &corev1.SecurityContext{
+			Privileged: spec.Privileged,
+		},
+		ReadinessProbe: spec.ReadinessProbe,
+		LivenessProbe:  spec.LivenessProbe,
+		StartupProbe:   spec.StartupProbe,
+		VolumeMounts:   volumeMounts,
+	}}
+}
+
+func (r *generic) getVolumes(name string, nc *invv1alpha1.NodeConfig) []corev1.Volume {
+	vols := append([]corev1.Volume{}, r.provider.Spec.Volumes...)
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vols = append(vols, corev1.Volume{
+			Name: pv.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: node.GetPersistentVolumeClaimName(name, pv.Name),
+				},
+			},
+		})
+	}
+	if nc.Spec.StartupConfig != nil {
+		vols = append(vols, corev1.Volume{
+			Name: startupConfigVolName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: node.GetStartupConfigMapName(name),
+					},
+				},
+			},
+		})
+	}
+	return vols
+}
+
+func (r *generic) getStartupConfigPath() string {
+	if r.provider.Spec.StartupConfigPath != "" {
+		return r.provider.Spec.StartupConfigPath
+	}
+	return defaultStartupConfigPath
+}
+
+// getResourceMap returns the resource list as the key/value map of the node config
+func getResourceMap(rl corev1.ResourceList) map[string]string {
+	m := make(map[string]string, len(rl))
+	for k, v := range rl {
+		m[string(k)] = v.String()
+	}
+	return m
+}
@@ -0,0 +1,346 @@
+package kubevirt
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	KubeVirtProvider     = "vm.kubevirt.io"
+	defaultVMImageName   = "quay.io/kubevirt/cirros-container-disk-demo:latest"
+	defaultVMModel       = "vm"
+	defaultInterfaceMTU  = 9000
+	vmInterfacePfx       = "net"
+	defaultDiskBus       = "virtio"
+	startupConfigSerial  = "startupconfig"
+	readinessTCPPort     = 22
+	rootDiskVolName      = "rootdisk"
+	startupConfigVolName = "startup-config"
+
+	//
+	terminationGracePeriodSeconds = 0
+	readinessInitialDelay         = 60
+	readinessPeriodSeconds        = 10
+	readinessFailureThreshold     = 30
+)
+
+var (
+	//nolint:gochecknoglobals
+	defaultResourceRequests = map[string]string{
+		"cpu":    "1",
+		"memory": "2Gi",
+	}
+	defaultResourceLimits = map[string]string{}
+)
+
+// Register registers the node in the NodeRegistry.
+func Register(r node.NodeRegistry) {
+	r.Register(KubeVirtProvider, func(c client.Client, s *runtime.Scheme) node.Node {
+		return &kubevirt{
+			Client: c,
+			scheme: s,
+		}
+	})
+}
+
+// kubevirt runs network operating systems that only ship as a vm image in a kubevirt
+// virtual machine instance. The image of the node config is a container disk that
+// holds the vm image.
+type kubevirt struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+func (r *kubevirt) GetProviderType(ctx context.Context) node.ProviderType {
+	return node.ProviderTypeVM
+}
+
+func (r *kubevirt) GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec {
+	return &invv1alpha1.NodeConfigSpec{
+		Provider: KubeVirtProvider,
+		Model:    pointer.String(defaultVMModel),
+		Image:    pointer.String(defaultVMImageName),
+	}
+}
+
+// ValidateNodeConfig validates that the node model of the model exists, the model
+// defines the interfaces of the vm.
+func (r *kubevirt) ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error {
+	if nc.Spec.Model == nil {
+		return nil
+	}
+	if _, err := r.GetNodeModel(ctx, nc); err != nil {
+		return fmt.Errorf("node model %s/%s of model %q not found, create it or use another model",
+			os.Getenv("POD_NAMESPACE"), getNodeModelName(nc), *nc.Spec.Model)
+	}
+	return nil
+}
+
+func (r *kubevirt) GetNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
+	// get nodeConfig by merging the provider defaults with the node config layers
+	return node.GetNodeConfig(ctx, r.Client, cr, r.GetNodeConfigDefaults(ctx))
+}
+
+func (r *kubevirt) GetNodeModelConfig(ctx context.Context, nc *invv1alpha1.NodeConfig) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: invv1alpha1.NodeKindAPIVersion,
+		Kind:       invv1alpha1.NodeModelKind,
+		Name:       getNodeModelName(nc),
+		Namespace:  os.Getenv("POD_NAMESPACE"),
+	}
+}
+
+func (r *kubevirt) GetNodeModel(ctx context.Context, nc *invv1alpha1.NodeConfig) (*invv1alpha1.NodeModel, error) {
+	nm := &invv1alpha1.NodeModel{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      getNodeModelName(nc),
+		Namespace: os.Getenv("POD_NAMESPACE"),
+	}, nm); err != nil {
+		return nil, err
+	}
+	return nm, nil
+}
+
+// GetNetworkAttachmentDefinitions returns a nad for every interface of the node model.
+// The interfaces are named netN in the launcher pod of the vm, which is the ordinal
+// naming kubevirt uses for the multus networks in the order of the node model.
+func (r *kubevirt) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultVMModel), err)
+	}
+	itfces := make([]node.Interface, 0, len(nm.Spec.Interfaces))
+	for i, itfce := range nm.Spec.Interfaces {
+		itfces = append(itfces, node.Interface{
+			Name:          itfce.Name,
+			ContainerName: fmt.Sprintf("%s%d", vmInterfacePfx, i+1),
+			MTU:           defaultInterfaceMTU,
+		})
+	}
+	return node.GetNetworkAttachmentDefinitions(cr, nc, itfces, r.scheme)
+}
+
+func (r *kubevirt) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
+	return node.GetPersistentVolumeClaims(cr, nc, r.scheme)
+}
+
+// GetStartupConfig returns the config map with the startup config of the node rendered
+// from the startup config templates referenced in the node config. The config map is
+// attached to the vm as a disk.
+func (r *kubevirt) GetStartupConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) (*corev1.ConfigMap, error) {
+	if nc.Spec.StartupConfig == nil {
+		return nil, nil
+	}
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultVMModel), err)
+	}
+	return node.GetStartupConfig(ctx, r.Client, cr, nc, nm, r.scheme)
+}
+
+// GetPodSpec is not supported since the node runs in a virtual machine instance.
+func (r *kubevirt) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*corev1.Pod, error) {
+	return nil, fmt.Errorf("provider %s runs nodes in a virtual machine instance, not in a pod", KubeVirtProvider)
+}
+
+// GetVirtualMachineInstanceSpec returns the virtual machine instance of the node. The
+// vm is connected to the pod network with masquerade and to the nads of the node with
+// a bridge, the labels and the affinity spread the launcher pods like the pods of the
+// other nodes.
+func (r *kubevirt) GetVirtualMachineInstanceSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*unstructured.Unstructured, error) {
+	startupConfig, err := r.GetStartupConfig(ctx, cr, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	res := nc.GetResourceRequirements(defaultResourceRequests, defaultResourceLimits)
+	resources, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&res)
+	if err != nil {
+		return nil, err
+	}
+	affinity, err := runtime.DefaultUnstructuredConverter.ToUnstructured(node.GetAffinity(cr.GetNamespace()))
+	if err != nil {
+		return nil, err
+	}
+
+	spec := map[string]any{
+		"terminationGracePeriodSeconds": int64(terminationGracePeriodSeconds),
+		"affinity":                      affinity,
+		"domain": map[string]any{
+			"resources": resources,
+			"devices": map[string]any{
+				"disks":      getDisks(nc),
+				"interfaces": getInterfaces(nads),
+			},
+		},
+		"networks": getNetworks(nads),
+		"volumes":  getVolumes(cr.GetName(), nc),
+		"readinessProbe": map[string]any{
+			"tcpSocket": map[string]any{
+				"port": int64(readinessTCPPort),
+			},
+			"initialDelaySeconds": int64(readinessInitialDelay),
+			"periodSeconds":       int64(readinessPeriodSeconds),
+			"failureThreshold":    int64(readinessFailureThreshold),
+		},
+	}
+
+	vmi := node.NewVirtualMachineInstance()
+	vmi.SetName(cr.GetName())
+	vmi.SetNamespace(cr.GetNamespace())
+	if err := unstructured.SetNestedMap(vmi.Object, spec, "spec"); err != nil {
+		return nil, err
+	}
+
+	// the networks of the vm are part of the spec, the startup config data
+	// is part of the hash since it is only read when the vm boots
+	var startupConfigData map[string]string
+	if startupConfig != nil {
+		startupConfigData = startupConfig.Data
+	}
+	hashString, err := node.GetHash([]any{spec, startupConfigData})
+	if err != nil {
+		return nil, err
+	}
+	vmi.SetAnnotations(map[string]string{
+		invv1alpha1.RevisionHash:    hashString,
+		invv1alpha1.NephioWiringKey: "true",
+	})
+	vmi.SetLabels(map[string]string{
+		invv1alpha1.NephioTopologyKey: cr.Namespace,
+	})
+
+	if err := ctrl.SetControllerReference(cr, vmi, r.scheme); err != nil {
+		return nil, err
+	}
+	return vmi, nil
+}
+
+// SetInitialConfig is not applicable, the vm is configured through its startup config.
+func (r *kubevirt) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	return nil
+}
+
+// GetInitialConfigHash returns an empty hash since there is no initial config.
+func (r *kubevirt) GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error) {
+	return "", nil
+}
+
+// GetDeviceInfo is not applicable since the operating system of the vm is not known.
+func (r *kubevirt) GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error) {
+	return &node.DeviceInfo{}, nil
+}
+
+// Teardown is not applicable.
+func (r *kubevirt) Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	return nil
+}
+
+func getNodeModelName(nc *invv1alpha1.NodeConfig) string {
+	return fmt.Sprintf("%s-%s", KubeVirtProvider, nc.GetModel(defaultVMModel))
+}
+
+// getNetworks returns the pod network and a multus network for every nad, the multus
+// networks are only added when the nads are deployed.
+func getNetworks(nads []*nadv1.NetworkAttachmentDefinition) []any {
+	networks := []any{
+		map[string]any{
+			"name": node.VirtualMachineInstancePodNetworkName,
+			"pod":  map[string]any{},
+		},
+	}
+	if os.Getenv("ENABLE_NAD") != "true" {
+		return networks
+	}
+	for _, n := range nads {
+		networks = append(networks, map[string]any{
+			"name": n.GetName(),
+			"multus": map[string]any{
+				"networkName": n.GetName(),
+			},
+		})
+	}
+	return networks
+}
+
+func getInterfaces(nads []*nadv1.NetworkAttachmentDefinition) []any {
+	itfces := []any{
+		map[string]any{
+			"name":       node.VirtualMachineInstancePodNetworkName,
+			"masquerade": map[string]any{},
+		},
+	}
+	if os.Getenv("ENABLE_NAD") != "true" {
+		return itfces
+	}
+	for _, n := range nads {
+		itfces = append(itfces, map[string]any{
+			"name":   n.GetName(),
+			"bridge": map[string]any{},
+		})
+	}
+	return itfces
+}
+
+func getDisks(nc *invv1alpha1.NodeConfig) []any {
+	disks := []any{
+		map[string]any{
+			"name": rootDiskVolName,
+			"disk": map[string]any{"bus": defaultDiskBus},
+		},
+	}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		disks = append(disks, map[string]any{
+			"name": pv.Name,
+			"disk": map[string]any{"bus": defaultDiskBus},
+		})
+	}
+	if nc.Spec.StartupConfig != nil {
+		disks = append(disks, map[string]any{
+			"name":   startupConfigVolName,
+			"serial": startupConfigSerial,
+			"disk":   map[string]any{"bus": defaultDiskBus},
+		})
+	}
+	return disks
+}
+
+func getVolumes(name string, nc *invv1alpha1.NodeConfig) []any {
+	vols := []any{
+		map[string]any{
+			"name": rootDiskVolName,
+			"containerDisk": map[string]any{
+				"image": nc.GetImage(defaultVMImageName),
+			},
+		},
+	}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vols = append(vols, map[string]any{
+			"name": pv.Name,
+			"persistentVolumeClaim": map[string]any{
+				"claimName": node.GetPersistentVolumeClaimName(name, pv.Name),
+			},
+		})
+	}
+	if nc.Spec.StartupConfig != nil {
+		vols = append(vols, map[string]any{
+			"name": startupConfigVolName,
+			"configMap": map[string]any{
+				"name": node.GetStartupConfigMapName(name),
+			},
+		})
+	}
+	return vols
+}
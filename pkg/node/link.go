@@ -0,0 +1,26 @@
+package node
+
+import (
+	"context"
+
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetLinks returns the links of the interfaces of the node keyed by the name of the
+// interface in the node model.
+func GetLinks(ctx context.Context, c client.Client, cr *invv1alpha1.Node) (map[string]*invv1alpha1.Link, error) {
+	links := &invv1alpha1.LinkList{}
+	if err := c.List(ctx, links, client.InNamespace(cr.GetNamespace())); err != nil {
+		return nil, err
+	}
+	linkedItfces := map[string]*invv1alpha1.Link{}
+	for i, link := range links.Items {
+		for _, ep := range link.Spec.Endpoints {
+			if ep.NodeName == cr.GetName() {
+				linkedItfces[ep.InterfaceName] = &links.Items[i]
+			}
+		}
+	}
+	return linkedItfces, nil
+}
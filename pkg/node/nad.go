@@ -0,0 +1,166 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// InterfacePluginsKey is the annotation of a node config that selects the cni plugin
+// of the interfaces of the node, the value is a json object of plugin configs keyed
+// by the name of the interface in the node model, e.g.
+// {"e1-1": {"type": "macvlan", "master": "eth1", "mode": "bridge"}}
+const InterfacePluginsKey = "node.nephio.com/interface-plugins"
+
+// InterfaceMTUsKey is the annotation of a node model that defines the mtu of the
+// interfaces of the model, the value is a json object of mtus keyed by the name of
+// the interface in the node model, e.g. {"e1-1": 9000, "e1-2": 1500}
+const InterfaceMTUsKey = "node.nephio.com/interface-mtus"
+
+// Interface defines the information of a node interface that is used
+// to build the network attachment definition of the interface.
+type Interface struct {
+	// Name of the interface as defined in the node model
+	Name string
+	// ContainerName is the name of the interface in the container of the node,
+	// when empty the name of the interface in the node model is used
+	ContainerName string
+	// MTU of the interface, when 0 the default mtu of the cni plugin is used
+	MTU int
+	// Plugin is the config of the cni plugin that implements the link of the
+	// interface, when nil the wire plugin is used
+	Plugin nad.PluginConfigInterface
+}
+
+// GetContainerName returns the name of the interface in the container of the node.
+func (r Interface) GetContainerName() string {
+	if r.ContainerName != "" {
+		return r.ContainerName
+	}
+	return r.Name
+}
+
+// GetPlugin returns the config of the cni plugin that implements the link of the
+// interface.
+func (r Interface) GetPlugin() nad.PluginConfigInterface {
+	if r.Plugin != nil {
+		return r.Plugin
+	}
+	return nad.WirePlugin{
+		PluginCniType: nad.PluginCniType{
+			Type: nad.WirePluginType,
+		},
+		InterfaceName: r.GetContainerName(),
+		MTU:           r.MTU,
+	}
+}
+
+// GetInterfaceMTUs returns the mtus of the interfaces of the node model keyed by the
+// name of the interface, the interfaces without an mtu use the default mtu of the provider.
+func GetInterfaceMTUs(nm *invv1alpha1.NodeModel) (map[string]int, error) {
+	mtus := map[string]int{}
+	v, ok := nm.GetAnnotations()[InterfaceMTUsKey]
+	if !ok {
+		return mtus, nil
+	}
+	if err := json.Unmarshal([]byte(v), &mtus); err != nil {
+		return nil, fmt.Errorf("cannot parse interface mtus of node model %s, err: %w", nm.GetName(), err)
+	}
+	for itfceName, mtu := range mtus {
+		if mtu <= 0 {
+			return nil, fmt.Errorf("invalid mtu %d of interface %s of node model %s", mtu, itfceName, nm.GetName())
+		}
+	}
+	return mtus, nil
+}
+
+// GetInterfacePlugins returns the plugin configs that the node config selects for the
+// interfaces of the node keyed by the name of the interface in the node model.
+func GetInterfacePlugins(nc *invv1alpha1.NodeConfig) (map[string]nad.PluginConfigInterface, error) {
+	plugins := map[string]nad.PluginConfigInterface{}
+	v, ok := nc.GetAnnotations()[InterfacePluginsKey]
+	if !ok {
+		return plugins, nil
+	}
+	configs := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(v), &configs); err != nil {
+		return nil, fmt.Errorf("cannot parse interface plugins of node config %s, err: %w", nc.GetName(), err)
+	}
+	for itfceName, b := range configs {
+		p, err := nad.ParsePluginConfig(b)
+		if err != nil {
+			return nil, fmt.Errorf("interface %s: %w", itfceName, err)
+		}
+		plugins[itfceName] = p
+	}
+	return plugins, nil
+}
+
+// GetNetworkAttachmentDefinitions returns the network attachment definitions of the
+// interfaces with the cni plugins selected by the node config, the other interfaces
+// use the plugin of the interface.
+func GetNetworkAttachmentDefinitions(cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, itfces []Interface, s *runtime.Scheme) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	plugins, err := GetInterfacePlugins(nc)
+	if err != nil {
+		return nil, err
+	}
+	for i, itfce := range itfces {
+		if p, ok := plugins[itfce.Name]; ok {
+			itfces[i].Plugin = p
+		}
+	}
+	return GetWireNetworkAttachmentDefinitions(cr, itfces, s)
+}
+
+// GetWireNetworkAttachmentDefinitions returns a network attachment definition
+// using the wire cni plugin for every interface, unless the interface selects
+// another cni plugin. The name of the nad
+// is composed of the node name and the interface name. The nad is labeled
+// with the node and interface name to select the nads of the node and it is
+// annotated with the name of the interface in the container, which is not part
+// of the config of every cni plugin.
+func GetWireNetworkAttachmentDefinitions(cr *invv1alpha1.Node, itfces []Interface, s *runtime.Scheme) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	nads := []*nadv1.NetworkAttachmentDefinition{}
+	for _, itfce := range itfces {
+		b, err := nad.GetNadConfig([]nad.PluginConfigInterface{
+			itfce.GetPlugin(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		n := &nadv1.NetworkAttachmentDefinition{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: nadv1.SchemeGroupVersion.Identifier(),
+				Kind:       reflect.TypeOf(nadv1.NetworkAttachmentDefinition{}).Name(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cr.GetNamespace(),
+				Name:      strings.Join([]string{cr.GetName(), itfce.Name}, "-"),
+				Labels: map[string]string{
+					invv1alpha1.NephioNodeNameKey:      cr.GetName(),
+					invv1alpha1.NephioInterfaceNameKey: itfce.Name,
+				},
+				Annotations: map[string]string{
+					nad.InterfaceNameKey: itfce.GetContainerName(),
+				},
+			},
+			Spec: nadv1.NetworkAttachmentDefinitionSpec{
+				Config: string(b),
+			},
+		}
+		if err := ctrl.SetControllerReference(cr, n, s); err != nil {
+			return nil, err
+		}
+		nads = append(nads, n)
+	}
+	return nads, nil
+}
@@ -14,17 +14,41 @@ type Node interface {
 	GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*corev1.Pod, error)
 	GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error)
 	GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error)
+	GetStartupConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) (*corev1.ConfigMap, error)
 	SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error
+	GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error)
+	GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*DeviceInfo, error)
+	Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error
 	// node configuration
 	GetNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error)
 	GetNodeModelConfig(ctx context.Context, nc *invv1alpha1.NodeConfig) *corev1.ObjectReference
 	GetNodeModel(ctx context.Context, nc *invv1alpha1.NodeConfig) (*invv1alpha1.NodeModel, error)
 	GetProviderType(ctx context.Context) ProviderType
+	// admission
+	GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec
+	ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error
 }
 
+// InitialConfigHashKey is the pod annotation that records the hash of the
+// initial config that was applied to the node running in the pod.
+const InitialConfigHashKey = "node.nephio.com/initial-config-hash"
+
+// WatchLabelKey is the label of the secrets and config maps that are read by the
+// operator, e.g. the credentials, licenses, certificates, variants and startup config
+// templates. The operator only caches and watches the secrets and config maps with
+// this label set to WatchLabelValue, the secrets and config maps are read from the
+// api server such that the unlabeled ones are found but do not trigger a reconcile.
+const (
+	WatchLabelKey   = "node.nephio.com/watch"
+	WatchLabelValue = "true"
+)
+
 type ProviderType string
 
 const (
 	ProviderTypeServer  ProviderType = "server"
 	ProviderTypeNetwork ProviderType = "network"
+	// ProviderTypeVM defines network nodes that run in a kubevirt virtual machine
+	// instance instead of a pod, the nodes implement the VirtualMachineNode interface
+	ProviderTypeVM ProviderType = "vm"
 )
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -13,7 +14,10 @@ type Initializer func(c client.Client, s *runtime.Scheme) Node
 
 type NodeRegistry interface {
 	Register(provider string, i Initializer)
+	Unregister(provider string)
+	IsRegistered(provider string) bool
 	NewNodeOfProvider(provider string, c client.Client, s *runtime.Scheme) (Node, error)
+	GetProviders() []string
 }
 
 func NewNodeRegistry() NodeRegistry {
@@ -22,16 +26,35 @@ func NewNodeRegistry() NodeRegistry {
 	}
 }
 
-// nodeRegistry implementation for fast lookup
+// nodeRegistry implementation for fast lookup, the providers are registered
+// at startup and dynamically by the node providers, hence the lock
 type nodeRegistry struct {
+	m         sync.RWMutex
 	nodeIndex map[string]Initializer
 }
 
 func (r *nodeRegistry) Register(provider string, i Initializer) {
+	r.m.Lock()
+	defer r.m.Unlock()
 	r.nodeIndex[provider] = i
 }
 
+func (r *nodeRegistry) Unregister(provider string) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	delete(r.nodeIndex, provider)
+}
+
+func (r *nodeRegistry) IsRegistered(provider string) bool {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	_, ok := r.nodeIndex[provider]
+	return ok
+}
+
 func (r *nodeRegistry) NewNodeOfProvider(provider string, c client.Client, s *runtime.Scheme) (Node, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
 	nodeInitializer, ok := r.nodeIndex[provider]
 	if !ok {
 		return nil, fmt.Errorf("provider %q is not supported. supported providers are %q", provider, strings.Join(r.getRegisteredProviderNodeNames(), ", "))
@@ -40,6 +63,13 @@ func (r *nodeRegistry) NewNodeOfProvider(provider string, c client.Client, s *ru
 	return nodeInitializer(c, s), nil
 }
 
+// GetProviders returns the sorted names of the registered providers.
+func (r *nodeRegistry) GetProviders() []string {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.getRegisteredProviderNodeNames()
+}
+
 func (r *nodeRegistry) getRegisteredProviderNodeNames() []string {
 	var result []string
 	for k := range r.nodeIndex {
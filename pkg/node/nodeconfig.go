@@ -0,0 +1,212 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultNodeConfigName is the name of the node config that applies to all nodes
+	// of the provider in the namespace of the node config
+	DefaultNodeConfigName = "default"
+	// NodeSelectorKey is the annotation of a node config with a label selector that
+	// selects the nodes the node config applies to, e.g. role=leaf
+	NodeSelectorKey = "node.nephio.com/node-selector"
+	// NodeConfigSourcesKey records the node config layers that are applied to the node
+	NodeConfigSourcesKey = "node.nephio.com/node-config-sources"
+	// providerDefaultsSource is the source of the provider defaults
+	providerDefaultsSource = "provider-defaults"
+)
+
+// mergedAnnotations are the annotations of the node config layers with a json object
+// value that are merged into the node config.
+//
+//nolint:gochecknoglobals
+var mergedAnnotations = []string{InterfacePluginsKey, PersistentVolumesKey}
+
+// GetNodeConfig resolves the node config of the node by merging the node config layers
+// below in order, where a later layer overwrites the fields set by an earlier layer.
+//  1. the defaults of the provider
+//  2. the default node config of the provider in the namespace of the operator
+//  3. the default node config of the provider in the namespace of the node
+//  4. the node configs of the provider that select the node through the node selector
+//     annotation, in order of their name
+//  5. the node config of the provider with the name of the node
+//  6. the node config that is referenced explicitly by the node, which must be of the
+//     provider of the node
+//
+// The layers are merged as json merge patches, so maps are merged and lists are replaced.
+// The json annotations of the layers, see mergedAnnotations, are merged in the same way.
+// The applied layers are recorded in the NodeConfigSourcesKey annotation of the node config.
+func GetNodeConfig(ctx context.Context, c client.Client, cr *invv1alpha1.Node, defaults *invv1alpha1.NodeConfigSpec) (*invv1alpha1.NodeConfig, error) {
+	layers, err := getNodeConfigLayers(ctx, c, cr)
+	if err != nil {
+		return nil, err
+	}
+
+	if defaults == nil {
+		defaults = &invv1alpha1.NodeConfigSpec{}
+	}
+	spec, err := json.Marshal(defaults)
+	if err != nil {
+		return nil, err
+	}
+	sources := []string{providerDefaultsSource}
+	name := ""
+	annotations := map[string][]byte{}
+	for _, layer := range layers {
+		patch, err := json.Marshal(layer.Spec)
+		if err != nil {
+			return nil, err
+		}
+		spec, err = jsonpatch.MergePatch(spec, patch)
+		if err != nil {
+			return nil, fmt.Errorf("cannot merge node config %s/%s, err: %w", layer.GetNamespace(), layer.GetName(), err)
+		}
+		for _, k := range mergedAnnotations {
+			v, ok := layer.GetAnnotations()[k]
+			if !ok {
+				continue
+			}
+			a, ok := annotations[k]
+			if !ok {
+				a = []byte("{}")
+			}
+			if annotations[k], err = jsonpatch.MergePatch(a, []byte(v)); err != nil {
+				return nil, fmt.Errorf("cannot merge annotation %s of node config %s/%s, err: %w", k, layer.GetNamespace(), layer.GetName(), err)
+			}
+		}
+		sources = append(sources, fmt.Sprintf("%s/%s", layer.GetNamespace(), layer.GetName()))
+		name = layer.GetName()
+	}
+
+	nc := &invv1alpha1.NodeConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: os.Getenv("POD_NAMESPACE"),
+			Name:      name,
+			Annotations: map[string]string{
+				NodeConfigSourcesKey: strings.Join(sources, ","),
+			},
+		},
+	}
+	for k, v := range annotations {
+		nc.Annotations[k] = string(v)
+	}
+	if err := json.Unmarshal(spec, &nc.Spec); err != nil {
+		return nil, err
+	}
+	return nc, nil
+}
+
+// getNodeConfigLayers returns the node configs that apply to the node in the order
+// they are merged.
+func getNodeConfigLayers(ctx context.Context, c client.Client, cr *invv1alpha1.Node) ([]invv1alpha1.NodeConfig, error) {
+	namespaces := []string{os.Getenv("POD_NAMESPACE")}
+	if cr.GetNamespace() != os.Getenv("POD_NAMESPACE") {
+		namespaces = append(namespaces, cr.GetNamespace())
+	}
+
+	defaultLayers := []invv1alpha1.NodeConfig{}
+	selectedLayers := []invv1alpha1.NodeConfig{}
+	nameLayers := []invv1alpha1.NodeConfig{}
+	for _, namespace := range namespaces {
+		ncl := &invv1alpha1.NodeConfigList{}
+		if err := c.List(ctx, ncl, client.InNamespace(namespace)); err != nil {
+			return nil, err
+		}
+		sort.Slice(ncl.Items, func(i, j int) bool {
+			return ncl.Items[i].GetName() < ncl.Items[j].GetName()
+		})
+		for _, nc := range ncl.Items {
+			if nc.Spec.Provider != cr.Spec.Provider {
+				continue
+			}
+			switch {
+			case nc.GetName() == DefaultNodeConfigName:
+				defaultLayers = append(defaultLayers, nc)
+			case nc.GetName() == cr.GetName():
+				nameLayers = append(nameLayers, nc)
+			default:
+				selected, err := SelectsNode(&nc, cr)
+				if err != nil {
+					return nil, err
+				}
+				if selected {
+					selectedLayers = append(selectedLayers, nc)
+				}
+			}
+		}
+	}
+
+	layers := append(defaultLayers, selectedLayers...)
+	layers = append(layers, nameLayers...)
+
+	if cr.Spec.NodeConfig != nil && cr.Spec.NodeConfig.Name != "" {
+		nc := &invv1alpha1.NodeConfig{}
+		if err := c.Get(ctx, types.NamespacedName{Name: cr.Spec.NodeConfig.Name, Namespace: os.Getenv("POD_NAMESPACE")}, nc); err != nil {
+			return nil, err
+		}
+		if nc.Spec.Provider != cr.Spec.Provider {
+			return nil, fmt.Errorf("node config %s/%s of provider %s cannot be used by a node of provider %s",
+				nc.GetNamespace(), nc.GetName(), nc.Spec.Provider, cr.Spec.Provider)
+		}
+		layers = append(layers, *nc)
+	}
+	return layers, nil
+}
+
+// AppliesToNode returns true when the node config is one of the node config layers
+// of the node, see GetNodeConfig.
+func AppliesToNode(nc *invv1alpha1.NodeConfig, cr *invv1alpha1.Node) (bool, error) {
+	if nc.GetNamespace() == os.Getenv("POD_NAMESPACE") && cr.Spec.NodeConfig != nil && cr.Spec.NodeConfig.Name == nc.GetName() {
+		return true, nil
+	}
+	if nc.Spec.Provider != cr.Spec.Provider {
+		return false, nil
+	}
+	if nc.GetNamespace() != os.Getenv("POD_NAMESPACE") && nc.GetNamespace() != cr.GetNamespace() {
+		return false, nil
+	}
+	if nc.GetName() == DefaultNodeConfigName || nc.GetName() == cr.GetName() {
+		return true, nil
+	}
+	return SelectsNode(nc, cr)
+}
+
+// SelectsNode returns true when the node selector annotation of the node config
+// matches the labels of the node.
+func SelectsNode(nc *invv1alpha1.NodeConfig, cr *invv1alpha1.Node) (bool, error) {
+	s, ok := nc.GetAnnotations()[NodeSelectorKey]
+	if !ok {
+		return false, nil
+	}
+	selector, err := labels.Parse(s)
+	if err != nil {
+		return false, fmt.Errorf("cannot parse node selector of node config %s, err: %w", nc.GetName(), err)
+	}
+	return selector.Matches(labels.Set(GetNodeLabels(cr))), nil
+}
+
+// GetNodeLabels returns the labels of the node merged with the user defined labels
+// in the spec of the node.
+func GetNodeLabels(cr *invv1alpha1.Node) map[string]string {
+	l := map[string]string{}
+	for k, v := range cr.Spec.Labels {
+		l[k] = v
+	}
+	for k, v := range cr.GetLabels() {
+		l[k] = v
+	}
+	return l
+}
@@ -0,0 +1,190 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testPodNamespace = "network-system"
+	testNamespace    = "default"
+	testProvider     = "test.nephio.org"
+)
+
+func getTestNodeConfig(namespace, name string, annotations map[string]string, spec invv1alpha1.NodeConfigSpec) *invv1alpha1.NodeConfig {
+	if spec.Provider == "" {
+		spec.Provider = testProvider
+	}
+	return &invv1alpha1.NodeConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Annotations: annotations,
+		},
+		Spec: spec,
+	}
+}
+
+func TestGetNodeConfig(t *testing.T) {
+	defaults := &invv1alpha1.NodeConfigSpec{
+		Model: pointer.String("defaults"),
+		Image: pointer.String("defaults"),
+	}
+
+	cases := map[string]struct {
+		ncs         []client.Object
+		nodeConfig  string
+		wantName    string
+		wantSpec    invv1alpha1.NodeConfigSpec
+		wantSources string
+		wantPlugins string
+		wantErr     bool
+	}{
+		"ProviderDefaults": {
+			wantSpec: invv1alpha1.NodeConfigSpec{
+				Model: pointer.String("defaults"),
+				Image: pointer.String("defaults"),
+			},
+			wantSources: "provider-defaults",
+		},
+		"LayerOrder": {
+			// every layer overwrites the image of the layers below, the model is only
+			// set by the default node config of the operator namespace
+			ncs: []client.Object{
+				getTestNodeConfig(testPodNamespace, "explicit", nil, invv1alpha1.NodeConfigSpec{Image: pointer.String("explicit")}),
+				getTestNodeConfig(testNamespace, "node1", nil, invv1alpha1.NodeConfigSpec{Image: pointer.String("name")}),
+				getTestNodeConfig(testNamespace, "leaf", map[string]string{NodeSelectorKey: "role=leaf"}, invv1alpha1.NodeConfigSpec{Image: pointer.String("selector")}),
+				getTestNodeConfig(testNamespace, DefaultNodeConfigName, nil, invv1alpha1.NodeConfigSpec{Image: pointer.String("namespace-default")}),
+				getTestNodeConfig(testPodNamespace, DefaultNodeConfigName, nil, invv1alpha1.NodeConfigSpec{Model: pointer.String("operator-default"), Image: pointer.String("operator-default")}),
+			},
+			nodeConfig: "explicit",
+			wantName:   "explicit",
+			wantSpec: invv1alpha1.NodeConfigSpec{
+				Provider: testProvider,
+				Model:    pointer.String("operator-default"),
+				Image:    pointer.String("explicit"),
+			},
+			wantSources: "provider-defaults,network-system/default,default/default,default/leaf,default/node1,network-system/explicit",
+		},
+		"ExplicitProviderMismatch": {
+			ncs: []client.Object{
+				getTestNodeConfig(testPodNamespace, "explicit", nil, invv1alpha1.NodeConfigSpec{Provider: "other.nephio.org", Image: pointer.String("other")}),
+			},
+			nodeConfig: "explicit",
+			wantErr:    true,
+		},
+		"ListReplacement": {
+			ncs: []client.Object{
+				getTestNodeConfig(testPodNamespace, DefaultNodeConfigName, nil, invv1alpha1.NodeConfigSpec{PersistentVolumes: []invv1alpha1.PersistentVolume{
+					{Name: "a", MountPath: "/a"},
+					{Name: "b", MountPath: "/b"},
+				}}),
+				getTestNodeConfig(testNamespace, "node1", nil, invv1alpha1.NodeConfigSpec{PersistentVolumes: []invv1alpha1.PersistentVolume{
+					{Name: "c", MountPath: "/c"},
+				}}),
+			},
+			wantName: "node1",
+			wantSpec: invv1alpha1.NodeConfigSpec{
+				Provider: testProvider,
+				Model:    pointer.String("defaults"),
+				Image:    pointer.String("defaults"),
+				PersistentVolumes: []invv1alpha1.PersistentVolume{
+					{Name: "c", MountPath: "/c"},
+				},
+			},
+			wantSources: "provider-defaults,network-system/default,default/node1",
+		},
+		"SelectorMatching": {
+			// the selected node configs are applied in order of their name, the node
+			// configs of other providers and the unselected node configs are skipped
+			ncs: []client.Object{
+				getTestNodeConfig(testNamespace, "leaf", map[string]string{NodeSelectorKey: "role=leaf"}, invv1alpha1.NodeConfigSpec{Image: pointer.String("leaf")}),
+				getTestNodeConfig(testNamespace, "all", map[string]string{NodeSelectorKey: "role"}, invv1alpha1.NodeConfigSpec{Image: pointer.String("all")}),
+				getTestNodeConfig(testNamespace, "spine", map[string]string{NodeSelectorKey: "role=spine"}, invv1alpha1.NodeConfigSpec{Image: pointer.String("spine")}),
+				getTestNodeConfig(testNamespace, "no-selector", nil, invv1alpha1.NodeConfigSpec{Image: pointer.String("no-selector")}),
+				getTestNodeConfig(testNamespace, "other", map[string]string{NodeSelectorKey: "role=leaf"}, invv1alpha1.NodeConfigSpec{Provider: "other.nephio.org", Image: pointer.String("other")}),
+			},
+			wantName: "leaf",
+			wantSpec: invv1alpha1.NodeConfigSpec{
+				Provider: testProvider,
+				Model:    pointer.String("defaults"),
+				Image:    pointer.String("leaf"),
+			},
+			wantSources: "provider-defaults,default/all,default/leaf",
+		},
+		"InterfacePlugins": {
+			ncs: []client.Object{
+				getTestNodeConfig(testPodNamespace, DefaultNodeConfigName, map[string]string{
+					InterfacePluginsKey: `{"e1-1":{"type":"macvlan","master":"eth1"},"e1-2":{"type":"bridge"}}`,
+				}, invv1alpha1.NodeConfigSpec{}),
+				getTestNodeConfig(testNamespace, "node1", map[string]string{
+					InterfacePluginsKey: `{"e1-2":{"type":"host-device","device":"ens1f1"}}`,
+				}, invv1alpha1.NodeConfigSpec{}),
+			},
+			wantName: "node1",
+			wantSpec: invv1alpha1.NodeConfigSpec{
+				Provider: testProvider,
+				Model:    pointer.String("defaults"),
+				Image:    pointer.String("defaults"),
+			},
+			wantSources: "provider-defaults,network-system/default,default/node1",
+			wantPlugins: `{"e1-1":{"master":"eth1","type":"macvlan"},"e1-2":{"device":"ens1f1","type":"host-device"}}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("POD_NAMESPACE", testPodNamespace)
+			s := runtime.NewScheme()
+			if err := invv1alpha1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			c := fake.NewClientBuilder().WithScheme(s).WithObjects(tc.ncs...).Build()
+
+			cr := &invv1alpha1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      "node1",
+				},
+				Spec: invv1alpha1.NodeSpec{
+					UserDefinedLabels: invv1alpha1.UserDefinedLabels{Labels: map[string]string{"role": "leaf"}},
+					Provider:          testProvider,
+				},
+			}
+			if tc.nodeConfig != "" {
+				cr.Spec.NodeConfig = &invv1alpha1.NodeConfigInfo{Name: tc.nodeConfig}
+			}
+
+			got, err := GetNodeConfig(context.Background(), c, cr, defaults)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("want error, got node config: %s", got.GetName())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.GetName() != tc.wantName {
+				t.Errorf("want name %q, got: %q", tc.wantName, got.GetName())
+			}
+			if diff := cmp.Diff(tc.wantSpec, got.Spec); diff != "" {
+				t.Errorf("spec -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantSources, got.GetAnnotations()[NodeConfigSourcesKey]); diff != "" {
+				t.Errorf("sources -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantPlugins, got.GetAnnotations()[InterfacePluginsKey]); diff != "" {
+				t.Errorf("interface plugins -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package plugin
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// the kubernetes resources are exchanged with the plugins as their json encoding
+
+func encode(obj any) ([]byte, error) {
+	return json.Marshal(obj)
+}
+
+func decode[T any](b []byte) (*T, error) {
+	obj := new(T)
+	if err := json.Unmarshal(b, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func encodeList[T any](objs []*T) ([][]byte, error) {
+	bs := make([][]byte, 0, len(objs))
+	for _, obj := range objs {
+		b, err := encode(obj)
+		if err != nil {
+			return nil, err
+		}
+		bs = append(bs, b)
+	}
+	return bs, nil
+}
+
+func decodeList[T any](bs [][]byte) ([]*T, error) {
+	objs := make([]*T, 0, len(bs))
+	for _, b := range bs {
+		obj, err := decode[T](b)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func encodePodIPs(ips []corev1.PodIP) []string {
+	s := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		s = append(s, ip.IP)
+	}
+	return s
+}
+
+func decodePodIPs(s []string) []corev1.PodIP {
+	ips := make([]corev1.PodIP, 0, len(s))
+	for _, ip := range s {
+		ips = append(ips, corev1.PodIP{IP: ip})
+	}
+	return ips
+}
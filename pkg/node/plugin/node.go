@@ -0,0 +1,275 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/plugin/nodepb"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// pluginNode implements the node of a provider that is served by a plugin, the
+// methods that need access to the api server are served by the operator.
+type pluginNode struct {
+	client.Client
+	scheme       *runtime.Scheme
+	plugin       nodepb.NodePluginClient
+	provider     string
+	providerType node.ProviderType
+}
+
+func (r *pluginNode) GetProviderType(ctx context.Context) node.ProviderType {
+	return r.providerType
+}
+
+// GetNodeConfigDefaults returns the defaults of the plugin, when the plugin cannot
+// be reached only the provider is defaulted.
+func (r *pluginNode) GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec {
+	defaults, err := r.getNodeConfigDefaults(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "cannot get node config defaults", "provider", r.provider)
+		return &invv1alpha1.NodeConfigSpec{Provider: r.provider}
+	}
+	return defaults
+}
+
+func (r *pluginNode) getNodeConfigDefaults(ctx context.Context) (*invv1alpha1.NodeConfigSpec, error) {
+	resp, err := r.plugin.GetNodeConfigDefaults(ctx, &nodepb.GetNodeConfigDefaultsRequest{})
+	if err != nil {
+		return nil, r.error(err)
+	}
+	return decode[invv1alpha1.NodeConfigSpec](resp.GetNodeConfigSpec())
+}
+
+func (r *pluginNode) ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error {
+	req := &nodepb.ValidateNodeConfigRequest{}
+	var err error
+	if req.Node, err = encode(cr); err != nil {
+		return err
+	}
+	if req.NodeConfig, err = encode(nc); err != nil {
+		return err
+	}
+	if _, err := r.plugin.ValidateNodeConfig(ctx, req); err != nil {
+		return r.error(err)
+	}
+	return nil
+}
+
+func (r *pluginNode) GetNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
+	defaults, err := r.getNodeConfigDefaults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// get nodeConfig by merging the plugin defaults with the node config layers
+	return node.GetNodeConfig(ctx, r.Client, cr, defaults)
+}
+
+// GetNodeModelConfig returns the node model reference of the plugin, nil when the
+// plugin cannot be reached.
+func (r *pluginNode) GetNodeModelConfig(ctx context.Context, nc *invv1alpha1.NodeConfig) *corev1.ObjectReference {
+	ref, err := r.getNodeModelConfig(ctx, nc)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "cannot get node model config", "provider", r.provider)
+		return nil
+	}
+	return ref
+}
+
+func (r *pluginNode) getNodeModelConfig(ctx context.Context, nc *invv1alpha1.NodeConfig) (*corev1.ObjectReference, error) {
+	b, err := encode(nc)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.plugin.GetNodeModelConfig(ctx, &nodepb.GetNodeModelConfigRequest{NodeConfig: b})
+	if err != nil {
+		return nil, r.error(err)
+	}
+	return decode[corev1.ObjectReference](resp.GetObjectReference())
+}
+
+func (r *pluginNode) GetNodeModel(ctx context.Context, nc *invv1alpha1.NodeConfig) (*invv1alpha1.NodeModel, error) {
+	ref, err := r.getNodeModelConfig(ctx, nc)
+	if err != nil {
+		return nil, err
+	}
+	nm := &invv1alpha1.NodeModel{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, nm); err != nil {
+		return nil, err
+	}
+	return nm, nil
+}
+
+func (r *pluginNode) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(""), err)
+	}
+	req := &nodepb.GetNetworkAttachmentDefinitionsRequest{}
+	if req.Node, err = encode(cr); err != nil {
+		return nil, err
+	}
+	if req.NodeConfig, err = encode(nc); err != nil {
+		return nil, err
+	}
+	if req.NodeModel, err = encode(nm); err != nil {
+		return nil, err
+	}
+	resp, err := r.plugin.GetNetworkAttachmentDefinitions(ctx, req)
+	if err != nil {
+		return nil, r.error(err)
+	}
+	nads, err := decodeList[nadv1.NetworkAttachmentDefinition](resp.GetNetworkAttachmentDefinitions())
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nads {
+		if err := ctrl.SetControllerReference(cr, n, r.scheme); err != nil {
+			return nil, err
+		}
+	}
+	return nads, nil
+}
+
+func (r *pluginNode) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
+	req := &nodepb.GetPersistentVolumeClaimsRequest{}
+	var err error
+	if req.Node, err = encode(cr); err != nil {
+		return nil, err
+	}
+	if req.NodeConfig, err = encode(nc); err != nil {
+		return nil, err
+	}
+	resp, err := r.plugin.GetPersistentVolumeClaims(ctx, req)
+	if err != nil {
+		return nil, r.error(err)
+	}
+	pvcs, err := decodeList[corev1.PersistentVolumeClaim](resp.GetPersistentVolumeClaims())
+	if err != nil {
+		return nil, err
+	}
+	for _, pvc := range pvcs {
+		if err := ctrl.SetControllerReference(cr, pvc, r.scheme); err != nil {
+			return nil, err
+		}
+	}
+	return pvcs, nil
+}
+
+// GetStartupConfig renders the startup config templates of the node config, the
+// plugin receives the startup config when building the pod.
+func (r *pluginNode) GetStartupConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) (*corev1.ConfigMap, error) {
+	if nc.Spec.StartupConfig == nil {
+		return nil, nil
+	}
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(""), err)
+	}
+	return node.GetStartupConfig(ctx, r.Client, cr, nc, nm, r.scheme)
+}
+
+func (r *pluginNode) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*corev1.Pod, error) {
+	startupConfig, err := r.GetStartupConfig(ctx, cr, nc)
+	if err != nil {
+		return nil, err
+	}
+	req := &nodepb.GetPodSpecRequest{}
+	if req.Node, err = encode(cr); err != nil {
+		return nil, err
+	}
+	if req.NodeConfig, err = encode(nc); err != nil {
+		return nil, err
+	}
+	if req.NetworkAttachmentDefinitions, err = encodeList(nads); err != nil {
+		return nil, err
+	}
+	if startupConfig != nil {
+		if req.StartupConfig, err = encode(startupConfig); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := r.plugin.GetPodSpec(ctx, req)
+	if err != nil {
+		return nil, r.error(err)
+	}
+	pod, err := decode[corev1.Pod](resp.GetPod())
+	if err != nil {
+		return nil, err
+	}
+	// the nads are only attached when enabled in the operator
+	if os.Getenv("ENABLE_NAD") != "true" {
+		delete(pod.Annotations, nadv1.NetworkAttachmentAnnot)
+	}
+	if err := ctrl.SetControllerReference(cr, pod, r.scheme); err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+func (r *pluginNode) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	b, err := encode(cr)
+	if err != nil {
+		return err
+	}
+	if _, err := r.plugin.SetInitialConfig(ctx, &nodepb.SetInitialConfigRequest{Node: b, PodIps: encodePodIPs(ips)}); err != nil {
+		return r.error(err)
+	}
+	return nil
+}
+
+func (r *pluginNode) GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error) {
+	b, err := encode(cr)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.plugin.GetInitialConfigHash(ctx, &nodepb.GetInitialConfigHashRequest{Node: b})
+	if err != nil {
+		return "", r.error(err)
+	}
+	return resp.GetHash(), nil
+}
+
+func (r *pluginNode) GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error) {
+	b, err := encode(cr)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.plugin.GetDeviceInfo(ctx, &nodepb.GetDeviceInfoRequest{Node: b, PodIps: encodePodIPs(ips)})
+	if err != nil {
+		return nil, r.error(err)
+	}
+	if !resp.GetFound() {
+		return nil, nil
+	}
+	return &node.DeviceInfo{
+		SoftwareVersion: resp.GetSoftwareVersion(),
+		Chassis:         resp.GetChassis(),
+	}, nil
+}
+
+func (r *pluginNode) Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	b, err := encode(cr)
+	if err != nil {
+		return err
+	}
+	if _, err := r.plugin.Teardown(ctx, &nodepb.TeardownRequest{Node: b, PodIps: encodePodIPs(ips)}); err != nil {
+		return r.error(err)
+	}
+	return nil
+}
+
+// error returns the error of the plugin without the grpc status details
+func (r *pluginNode) error(err error) error {
+	return fmt.Errorf("plugin %s: %s", r.provider, status.Convert(err).Message())
+}
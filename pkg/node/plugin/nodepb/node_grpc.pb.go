@@ -0,0 +1,501 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.21.12
+// source: pkg/node/plugin/nodepb/node.proto
+
+package nodepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	NodePlugin_GetProvider_FullMethodName                     = "/nodepb.NodePlugin/GetProvider"
+	NodePlugin_GetNodeConfigDefaults_FullMethodName           = "/nodepb.NodePlugin/GetNodeConfigDefaults"
+	NodePlugin_ValidateNodeConfig_FullMethodName              = "/nodepb.NodePlugin/ValidateNodeConfig"
+	NodePlugin_GetNodeModelConfig_FullMethodName              = "/nodepb.NodePlugin/GetNodeModelConfig"
+	NodePlugin_GetNetworkAttachmentDefinitions_FullMethodName = "/nodepb.NodePlugin/GetNetworkAttachmentDefinitions"
+	NodePlugin_GetPersistentVolumeClaims_FullMethodName       = "/nodepb.NodePlugin/GetPersistentVolumeClaims"
+	NodePlugin_GetPodSpec_FullMethodName                      = "/nodepb.NodePlugin/GetPodSpec"
+	NodePlugin_SetInitialConfig_FullMethodName                = "/nodepb.NodePlugin/SetInitialConfig"
+	NodePlugin_GetInitialConfigHash_FullMethodName            = "/nodepb.NodePlugin/GetInitialConfigHash"
+	NodePlugin_GetDeviceInfo_FullMethodName                   = "/nodepb.NodePlugin/GetDeviceInfo"
+	NodePlugin_Teardown_FullMethodName                        = "/nodepb.NodePlugin/Teardown"
+)
+
+// NodePluginClient is the client API for NodePlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NodePluginClient interface {
+	// GetProvider returns the provider that is served by the plugin
+	GetProvider(ctx context.Context, in *GetProviderRequest, opts ...grpc.CallOption) (*GetProviderResponse, error)
+	// GetNodeConfigDefaults returns the defaults of the node config spec
+	GetNodeConfigDefaults(ctx context.Context, in *GetNodeConfigDefaultsRequest, opts ...grpc.CallOption) (*GetNodeConfigDefaultsResponse, error)
+	// ValidateNodeConfig validates the node config of the node
+	ValidateNodeConfig(ctx context.Context, in *ValidateNodeConfigRequest, opts ...grpc.CallOption) (*ValidateNodeConfigResponse, error)
+	// GetNodeModelConfig returns the reference to the node model of the node config
+	GetNodeModelConfig(ctx context.Context, in *GetNodeModelConfigRequest, opts ...grpc.CallOption) (*GetNodeModelConfigResponse, error)
+	// GetNetworkAttachmentDefinitions returns the nads of the interfaces of the node
+	GetNetworkAttachmentDefinitions(ctx context.Context, in *GetNetworkAttachmentDefinitionsRequest, opts ...grpc.CallOption) (*GetNetworkAttachmentDefinitionsResponse, error)
+	// GetPersistentVolumeClaims returns the pvcs of the node
+	GetPersistentVolumeClaims(ctx context.Context, in *GetPersistentVolumeClaimsRequest, opts ...grpc.CallOption) (*GetPersistentVolumeClaimsResponse, error)
+	// GetPodSpec returns the pod of the node
+	GetPodSpec(ctx context.Context, in *GetPodSpecRequest, opts ...grpc.CallOption) (*GetPodSpecResponse, error)
+	// SetInitialConfig provisions the initial config of the node
+	SetInitialConfig(ctx context.Context, in *SetInitialConfigRequest, opts ...grpc.CallOption) (*SetInitialConfigResponse, error)
+	// GetInitialConfigHash returns the hash of the initial config of the node
+	GetInitialConfigHash(ctx context.Context, in *GetInitialConfigHashRequest, opts ...grpc.CallOption) (*GetInitialConfigHashResponse, error)
+	// GetDeviceInfo returns the information that is read back from the node
+	GetDeviceInfo(ctx context.Context, in *GetDeviceInfoRequest, opts ...grpc.CallOption) (*GetDeviceInfoResponse, error)
+	// Teardown cleans up the node before it is deleted
+	Teardown(ctx context.Context, in *TeardownRequest, opts ...grpc.CallOption) (*TeardownResponse, error)
+}
+
+type nodePluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNodePluginClient(cc grpc.ClientConnInterface) NodePluginClient {
+	return &nodePluginClient{cc}
+}
+
+func (c *nodePluginClient) GetProvider(ctx context.Context, in *GetProviderRequest, opts ...grpc.CallOption) (*GetProviderResponse, error) {
+	out := new(GetProviderResponse)
+	err := c.cc.Invoke(ctx, NodePlugin_GetProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePluginClient) GetNodeConfigDefaults(ctx context.Context, in *GetNodeConfigDefaultsRequest, opts ...grpc.CallOption) (*GetNodeConfigDefaultsResponse, error) {
+	out := new(GetNodeConfigDefaultsResponse)
+	err := c.cc.Invoke(ctx, NodePlugin_GetNodeConfigDefaults_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePluginClient) ValidateNodeConfig(ctx context.Context, in *ValidateNodeConfigRequest, opts ...grpc.CallOption) (*ValidateNodeConfigResponse, error) {
+	out := new(ValidateNodeConfigResponse)
+	err := c.cc.Invoke(ctx, NodePlugin_ValidateNodeConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePluginClient) GetNodeModelConfig(ctx context.Context, in *GetNodeModelConfigRequest, opts ...grpc.CallOption) (*GetNodeModelConfigResponse, error) {
+	out := new(GetNodeModelConfigResponse)
+	err := c.cc.Invoke(ctx, NodePlugin_GetNodeModelConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePluginClient) GetNetworkAttachmentDefinitions(ctx context.Context, in *GetNetworkAttachmentDefinitionsRequest, opts ...grpc.CallOption) (*GetNetworkAttachmentDefinitionsResponse, error) {
+	out := new(GetNetworkAttachmentDefinitionsResponse)
+	err := c.cc.Invoke(ctx, NodePlugin_GetNetworkAttachmentDefinitions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePluginClient) GetPersistentVolumeClaims(ctx context.Context, in *GetPersistentVolumeClaimsRequest, opts ...grpc.CallOption) (*GetPersistentVolumeClaimsResponse, error) {
+	out := new(GetPersistentVolumeClaimsResponse)
+	err := c.cc.Invoke(ctx, NodePlugin_GetPersistentVolumeClaims_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePluginClient) GetPodSpec(ctx context.Context, in *GetPodSpecRequest, opts ...grpc.CallOption) (*GetPodSpecResponse, error) {
+	out := new(GetPodSpecResponse)
+	err := c.cc.Invoke(ctx, NodePlugin_GetPodSpec_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePluginClient) SetInitialConfig(ctx context.Context, in *SetInitialConfigRequest, opts ...grpc.CallOption) (*SetInitialConfigResponse, error) {
+	out := new(SetInitialConfigResponse)
+	err := c.cc.Invoke(ctx, NodePlugin_SetInitialConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePluginClient) GetInitialConfigHash(ctx context.Context, in *GetInitialConfigHashRequest, opts ...grpc.CallOption) (*GetInitialConfigHashResponse, error) {
+	out := new(GetInitialConfigHashResponse)
+	err := c.cc.Invoke(ctx, NodePlugin_GetInitialConfigHash_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePluginClient) GetDeviceInfo(ctx context.Context, in *GetDeviceInfoRequest, opts ...grpc.CallOption) (*GetDeviceInfoResponse, error) {
+	out := new(GetDeviceInfoResponse)
+	err := c.cc.Invoke(ctx, NodePlugin_GetDeviceInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodePluginClient) Teardown(ctx context.Context, in *TeardownRequest, opts ...grpc.CallOption) (*TeardownResponse, error) {
+	out := new(TeardownResponse)
+	err := c.cc.Invoke(ctx, NodePlugin_Teardown_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NodePluginServer is the server API for NodePlugin service.
+// All implementations must embed UnimplementedNodePluginServer
+// for forward compatibility
+type NodePluginServer interface {
+	// GetProvider returns the provider that is served by the plugin
+	GetProvider(context.Context, *GetProviderRequest) (*GetProviderResponse, error)
+	// GetNodeConfigDefaults returns the defaults of the node config spec
+	GetNodeConfigDefaults(context.Context, *GetNodeConfigDefaultsRequest) (*GetNodeConfigDefaultsResponse, error)
+	// ValidateNodeConfig validates the node config of the node
+	ValidateNodeConfig(context.Context, *ValidateNodeConfigRequest) (*ValidateNodeConfigResponse, error)
+	// GetNodeModelConfig returns the reference to the node model of the node config
+	GetNodeModelConfig(context.Context, *GetNodeModelConfigRequest) (*GetNodeModelConfigResponse, error)
+	// GetNetworkAttachmentDefinitions returns the nads of the interfaces of the node
+	GetNetworkAttachmentDefinitions(context.Context, *GetNetworkAttachmentDefinitionsRequest) (*GetNetworkAttachmentDefinitionsResponse, error)
+	// GetPersistentVolumeClaims returns the pvcs of the node
+	GetPersistentVolumeClaims(context.Context, *GetPersistentVolumeClaimsRequest) (*GetPersistentVolumeClaimsResponse, error)
+	// GetPodSpec returns the pod of the node
+	GetPodSpec(context.Context, *GetPodSpecRequest) (*GetPodSpecResponse, error)
+	// SetInitialConfig provisions the initial config of the node
+	SetInitialConfig(context.Context, *SetInitialConfigRequest) (*SetInitialConfigResponse, error)
+	// GetInitialConfigHash returns the hash of the initial config of the node
+	GetInitialConfigHash(context.Context, *GetInitialConfigHashRequest) (*GetInitialConfigHashResponse, error)
+	// GetDeviceInfo returns the information that is read back from the node
+	GetDeviceInfo(context.Context, *GetDeviceInfoRequest) (*GetDeviceInfoResponse, error)
+	// Teardown cleans up the node before it is deleted
+	Teardown(context.Context, *TeardownRequest) (*TeardownResponse, error)
+	mustEmbedUnimplementedNodePluginServer()
+}
+
+// UnimplementedNodePluginServer must be embedded to have forward compatible implementations.
+type UnimplementedNodePluginServer struct {
+}
+
+func (UnimplementedNodePluginServer) GetProvider(context.Context, *GetProviderRequest) (*GetProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProvider not implemented")
+}
+func (UnimplementedNodePluginServer) GetNodeConfigDefaults(context.Context, *GetNodeConfigDefaultsRequest) (*GetNodeConfigDefaultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeConfigDefaults not implemented")
+}
+func (UnimplementedNodePluginServer) ValidateNodeConfig(context.Context, *ValidateNodeConfigRequest) (*ValidateNodeConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateNodeConfig not implemented")
+}
+func (UnimplementedNodePluginServer) GetNodeModelConfig(context.Context, *GetNodeModelConfigRequest) (*GetNodeModelConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeModelConfig not implemented")
+}
+func (UnimplementedNodePluginServer) GetNetworkAttachmentDefinitions(context.Context, *GetNetworkAttachmentDefinitionsRequest) (*GetNetworkAttachmentDefinitionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkAttachmentDefinitions not implemented")
+}
+func (UnimplementedNodePluginServer) GetPersistentVolumeClaims(context.Context, *GetPersistentVolumeClaimsRequest) (*GetPersistentVolumeClaimsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPersistentVolumeClaims not implemented")
+}
+func (UnimplementedNodePluginServer) GetPodSpec(context.Context, *GetPodSpecRequest) (*GetPodSpecResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPodSpec not implemented")
+}
+func (UnimplementedNodePluginServer) SetInitialConfig(context.Context, *SetInitialConfigRequest) (*SetInitialConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetInitialConfig not implemented")
+}
+func (UnimplementedNodePluginServer) GetInitialConfigHash(context.Context, *GetInitialConfigHashRequest) (*GetInitialConfigHashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInitialConfigHash not implemented")
+}
+func (UnimplementedNodePluginServer) GetDeviceInfo(context.Context, *GetDeviceInfoRequest) (*GetDeviceInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceInfo not implemented")
+}
+func (UnimplementedNodePluginServer) Teardown(context.Context, *TeardownRequest) (*TeardownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Teardown not implemented")
+}
+func (UnimplementedNodePluginServer) mustEmbedUnimplementedNodePluginServer() {}
+
+// UnsafeNodePluginServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NodePluginServer will
+// result in compilation errors.
+type UnsafeNodePluginServer interface {
+	mustEmbedUnimplementedNodePluginServer()
+}
+
+func RegisterNodePluginServer(s grpc.ServiceRegistrar, srv NodePluginServer) {
+	s.RegisterService(&NodePlugin_ServiceDesc, srv)
+}
+
+func _NodePlugin_GetProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePluginServer).GetProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodePlugin_GetProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePluginServer).GetProvider(ctx, req.(*GetProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePlugin_GetNodeConfigDefaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeConfigDefaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePluginServer).GetNodeConfigDefaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodePlugin_GetNodeConfigDefaults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePluginServer).GetNodeConfigDefaults(ctx, req.(*GetNodeConfigDefaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePlugin_ValidateNodeConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateNodeConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePluginServer).ValidateNodeConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodePlugin_ValidateNodeConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePluginServer).ValidateNodeConfig(ctx, req.(*ValidateNodeConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePlugin_GetNodeModelConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeModelConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePluginServer).GetNodeModelConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodePlugin_GetNodeModelConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePluginServer).GetNodeModelConfig(ctx, req.(*GetNodeModelConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePlugin_GetNetworkAttachmentDefinitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNetworkAttachmentDefinitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePluginServer).GetNetworkAttachmentDefinitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodePlugin_GetNetworkAttachmentDefinitions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePluginServer).GetNetworkAttachmentDefinitions(ctx, req.(*GetNetworkAttachmentDefinitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePlugin_GetPersistentVolumeClaims_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPersistentVolumeClaimsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePluginServer).GetPersistentVolumeClaims(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodePlugin_GetPersistentVolumeClaims_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePluginServer).GetPersistentVolumeClaims(ctx, req.(*GetPersistentVolumeClaimsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePlugin_GetPodSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPodSpecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePluginServer).GetPodSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodePlugin_GetPodSpec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePluginServer).GetPodSpec(ctx, req.(*GetPodSpecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePlugin_SetInitialConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetInitialConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePluginServer).SetInitialConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodePlugin_SetInitialConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePluginServer).SetInitialConfig(ctx, req.(*SetInitialConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePlugin_GetInitialConfigHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInitialConfigHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePluginServer).GetInitialConfigHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodePlugin_GetInitialConfigHash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePluginServer).GetInitialConfigHash(ctx, req.(*GetInitialConfigHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePlugin_GetDeviceInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePluginServer).GetDeviceInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodePlugin_GetDeviceInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePluginServer).GetDeviceInfo(ctx, req.(*GetDeviceInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodePlugin_Teardown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TeardownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodePluginServer).Teardown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodePlugin_Teardown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodePluginServer).Teardown(ctx, req.(*TeardownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NodePlugin_ServiceDesc is the grpc.ServiceDesc for NodePlugin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NodePlugin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "nodepb.NodePlugin",
+	HandlerType: (*NodePluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProvider",
+			Handler:    _NodePlugin_GetProvider_Handler,
+		},
+		{
+			MethodName: "GetNodeConfigDefaults",
+			Handler:    _NodePlugin_GetNodeConfigDefaults_Handler,
+		},
+		{
+			MethodName: "ValidateNodeConfig",
+			Handler:    _NodePlugin_ValidateNodeConfig_Handler,
+		},
+		{
+			MethodName: "GetNodeModelConfig",
+			Handler:    _NodePlugin_GetNodeModelConfig_Handler,
+		},
+		{
+			MethodName: "GetNetworkAttachmentDefinitions",
+			Handler:    _NodePlugin_GetNetworkAttachmentDefinitions_Handler,
+		},
+		{
+			MethodName: "GetPersistentVolumeClaims",
+			Handler:    _NodePlugin_GetPersistentVolumeClaims_Handler,
+		},
+		{
+			MethodName: "GetPodSpec",
+			Handler:    _NodePlugin_GetPodSpec_Handler,
+		},
+		{
+			MethodName: "SetInitialConfig",
+			Handler:    _NodePlugin_SetInitialConfig_Handler,
+		},
+		{
+			MethodName: "GetInitialConfigHash",
+			Handler:    _NodePlugin_GetInitialConfigHash_Handler,
+		},
+		{
+			MethodName: "GetDeviceInfo",
+			Handler:    _NodePlugin_GetDeviceInfo_Handler,
+		},
+		{
+			MethodName: "Teardown",
+			Handler:    _NodePlugin_Teardown_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/node/plugin/nodepb/node.proto",
+}
@@ -0,0 +1,132 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/plugin/nodepb"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultDir is the directory the operator discovers the plugins from
+	DefaultDir = "/etc/network-node/plugins"
+	// discoveryTimeout bounds the time to wait for a plugin to serve at startup
+	discoveryTimeout = 30 * time.Second
+)
+
+// Plugin is the node provider that is served out of process by a plugin. It is the
+// node.Node interface without the methods that need access to the api server, these
+// are served by the operator, see nodepb.NodePlugin. The nodes and node configs are
+// provided by the operator and the owner references of the returned resources are
+// set by the operator.
+type Plugin interface {
+	GetProvider(ctx context.Context) (string, node.ProviderType)
+	GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec
+	ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error
+	GetNodeModelConfig(ctx context.Context, nc *invv1alpha1.NodeConfig) *corev1.ObjectReference
+	GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nm *invv1alpha1.NodeModel) ([]*nadv1.NetworkAttachmentDefinition, error)
+	GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error)
+	GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition, startupConfig *corev1.ConfigMap) (*corev1.Pod, error)
+	SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error
+	GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error)
+	GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error)
+	Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error
+}
+
+// Config defines how to reach a plugin, every file in the plugin directory
+// holds the config of one plugin.
+type Config struct {
+	// Address of the grpc server of the plugin, e.g. unix:///var/run/plugins/acme.sock
+	// for a sidecar or acme-plugin.network-system.svc:9000 for a separate deployment
+	Address string `json:"address"`
+}
+
+// Discover registers the plugins configured in the directory in the NodeRegistry,
+// next to the providers that are compiled in. The plugins are expected to serve
+// within the discovery timeout. A directory that does not exist has no plugins.
+func Discover(ctx context.Context, r node.NodeRegistry, dir string) ([]string, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	providers := []string{}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		switch filepath.Ext(f.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		cfg := Config{}
+		if err := yaml.Unmarshal(b, &cfg); err != nil {
+			return nil, fmt.Errorf("cannot parse plugin config %s, err: %w", f.Name(), err)
+		}
+		if cfg.Address == "" {
+			return nil, fmt.Errorf("plugin config %s has no address", f.Name())
+		}
+		conn, err := grpc.Dial(cfg.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot dial plugin %s, err: %w", cfg.Address, err)
+		}
+		provider, err := Register(ctx, r, nodepb.NewNodePluginClient(conn))
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("cannot register plugin %s, err: %w", cfg.Address, err)
+		}
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
+
+// Register registers the provider served by the plugin in the NodeRegistry. The
+// providers that are already registered cannot be overridden by a plugin.
+func Register(ctx context.Context, r node.NodeRegistry, pc nodepb.NodePluginClient) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+	resp, err := pc.GetProvider(ctx, &nodepb.GetProviderRequest{}, grpc.WaitForReady(true))
+	if err != nil {
+		return "", err
+	}
+	if resp.GetName() == "" {
+		return "", fmt.Errorf("plugin has no provider name")
+	}
+	if r.IsRegistered(resp.GetName()) {
+		return "", fmt.Errorf("provider %q is already registered", resp.GetName())
+	}
+	providerType := node.ProviderTypeNetwork
+	if resp.GetType() == string(node.ProviderTypeServer) {
+		providerType = node.ProviderTypeServer
+	}
+	provider := resp.GetName()
+	r.Register(provider, func(c client.Client, s *runtime.Scheme) node.Node {
+		return &pluginNode{
+			Client:       c,
+			scheme:       s,
+			plugin:       pc,
+			provider:     provider,
+			providerType: providerType,
+		}
+	})
+	return provider, nil
+}
@@ -0,0 +1,158 @@
+package plugin_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/plugin"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/plugin/nodepb"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/plugin/sample"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testNamespace = "network-system"
+	testTopology  = "topo"
+)
+
+// startPlugin serves the sample plugin in process and returns the client of the plugin
+func startPlugin(t *testing.T) nodepb.NodePluginClient {
+	t.Helper()
+	p, err := sample.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis := bufconn.Listen(1024 * 1024)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- plugin.Serve(ctx, lis, p) }()
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("serve: %v", err)
+		}
+	})
+	return nodepb.NewNodePluginClient(conn)
+}
+
+func TestPlugin(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", testNamespace)
+	t.Setenv("ENABLE_NAD", "")
+	ctx := context.Background()
+
+	nr := node.NewNodeRegistry()
+	pc := startPlugin(t)
+	provider, err := plugin.Register(ctx, nr, pc)
+	if err != nil {
+		t.Fatalf("cannot register plugin: %v", err)
+	}
+	if provider != sample.SampleProvider {
+		t.Fatalf("want provider %s, got %s", sample.SampleProvider, provider)
+	}
+	// a provider cannot be registered twice
+	if _, err := plugin.Register(ctx, nr, pc); err == nil {
+		t.Fatalf("want error registering the provider twice")
+	}
+
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := invv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := nadv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	cr := &invv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "server1", Namespace: testTopology, UID: "1"},
+		Spec:       invv1alpha1.NodeSpec{Provider: sample.SampleProvider},
+	}
+	nm := &invv1alpha1.NodeModel{
+		ObjectMeta: metav1.ObjectMeta{Name: sample.SampleProvider + "-default", Namespace: testNamespace},
+		Spec: invv1alpha1.NodeModelSpec{
+			Interfaces: []invv1alpha1.NodeModelInterface{{Name: "e1"}, {Name: "e2"}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(cr, nm).Build()
+
+	n, err := nr.NewNodeOfProvider(sample.SampleProvider, c, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := n.GetProviderType(ctx); got != node.ProviderTypeServer {
+		t.Errorf("want provider type %s, got %s", node.ProviderTypeServer, got)
+	}
+
+	nc, err := n.GetNodeConfig(ctx, cr)
+	if err != nil {
+		t.Fatalf("cannot get node config: %v", err)
+	}
+	if got := nc.GetModel(""); got != "default" {
+		t.Errorf("want model default, got %s", got)
+	}
+
+	// the error of the plugin is returned without the grpc status details
+	invalid := nc.DeepCopy()
+	invalid.Spec.Model = pointer.String("unknown")
+	err = n.ValidateNodeConfig(ctx, cr, invalid)
+	if err == nil || strings.Contains(err.Error(), "rpc error") || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("want validation error of the plugin, got %v", err)
+	}
+
+	nads, err := n.GetNetworkAttachmentDefinitions(ctx, cr, nc)
+	if err != nil {
+		t.Fatalf("cannot get nads: %v", err)
+	}
+	if len(nads) != 2 {
+		t.Fatalf("want 2 nads, got %d", len(nads))
+	}
+	for i, itfce := range []string{"eth1", "eth2"} {
+		if !strings.Contains(nads[i].Spec.Config, itfce) {
+			t.Errorf("want nad %s with interface %s, got %s", nads[i].GetName(), itfce, nads[i].Spec.Config)
+		}
+		if ref := metav1.GetControllerOf(nads[i]); ref == nil || ref.UID != cr.GetUID() {
+			t.Errorf("want nad %s controlled by the node, got %v", nads[i].GetName(), ref)
+		}
+	}
+
+	pod, err := n.GetPodSpec(ctx, cr, nc, nads)
+	if err != nil {
+		t.Fatalf("cannot get pod: %v", err)
+	}
+	if ref := metav1.GetControllerOf(pod); ref == nil || ref.UID != cr.GetUID() {
+		t.Errorf("want pod controlled by the node, got %v", ref)
+	}
+	if _, ok := pod.GetAnnotations()[nadv1.NetworkAttachmentAnnot]; ok {
+		t.Errorf("want no nad annotation when nads are not enabled")
+	}
+	if pod.GetAnnotations()[invv1alpha1.RevisionHash] == "" {
+		t.Errorf("want revision hash")
+	}
+
+	info, err := n.GetDeviceInfo(ctx, cr, nil)
+	if err != nil || info != nil {
+		t.Errorf("want no device info, got %v, err: %v", info, err)
+	}
+}
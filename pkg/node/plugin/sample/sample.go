@@ -0,0 +1,212 @@
+package sample
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/plugin"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	SampleProvider          = "sample.example.com"
+	defaultSampleModel      = "default"
+	defaultSampleImageName  = "ghcr.io/hellt/network-multitool:latest"
+	terminationGracePeriods = 0
+	startupConfigVolName    = "startup-config"
+	startupConfigMountPath  = "/etc/startup-config"
+)
+
+var (
+	//nolint:gochecknoglobals
+	defaultResourceRequests = map[string]string{
+		"cpu":    "100m",
+		"memory": "128Mi",
+	}
+	defaultResourceLimits = map[string]string{}
+)
+
+// New returns the sample plugin, a server with the interfaces of the node model
+// named eth1..N. The plugin shows the contract of an out of process provider.
+func New() (plugin.Plugin, error) {
+	s := runtime.NewScheme()
+	if err := invv1alpha1.AddToScheme(s); err != nil {
+		return nil, err
+	}
+	return &sample{scheme: s}, nil
+}
+
+type sample struct {
+	// scheme is used to build the resources of the node,
+	// the plugin has no access to the api server
+	scheme *runtime.Scheme
+}
+
+func (r *sample) GetProvider(ctx context.Context) (string, node.ProviderType) {
+	return SampleProvider, node.ProviderTypeServer
+}
+
+func (r *sample) GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec {
+	return &invv1alpha1.NodeConfigSpec{
+		Provider: SampleProvider,
+		Model:    pointer.String(defaultSampleModel),
+		Image:    pointer.String(defaultSampleImageName),
+	}
+}
+
+// ValidateNodeConfig validates the model, the sample only supports the default model.
+func (r *sample) ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error {
+	if nc.Spec.Model != nil && *nc.Spec.Model != defaultSampleModel {
+		return fmt.Errorf("model %q is not supported, supported models are %q", *nc.Spec.Model, defaultSampleModel)
+	}
+	return nil
+}
+
+// GetNodeModelConfig returns the node model in the namespace of the node config,
+// which is the namespace of the operator.
+func (r *sample) GetNodeModelConfig(ctx context.Context, nc *invv1alpha1.NodeConfig) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: invv1alpha1.NodeKindAPIVersion,
+		Kind:       invv1alpha1.NodeModelKind,
+		Name:       fmt.Sprintf("%s-%s", SampleProvider, nc.GetModel(defaultSampleModel)),
+		Namespace:  nc.GetNamespace(),
+	}
+}
+
+func (r *sample) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nm *invv1alpha1.NodeModel) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	itfces := make([]node.Interface, 0, len(nm.Spec.Interfaces))
+	for i, itfce := range nm.Spec.Interfaces {
+		itfces = append(itfces, node.Interface{
+			Name:          itfce.Name,
+			ContainerName: fmt.Sprintf("eth%d", i+1),
+		})
+	}
+	return node.GetNetworkAttachmentDefinitions(cr, nc, itfces, r.scheme)
+}
+
+func (r *sample) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
+	return node.GetPersistentVolumeClaims(cr, nc, r.scheme)
+}
+
+func (r *sample) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition, startupConfig *corev1.ConfigMap) (*corev1.Pod, error) {
+	nadAnnotation, err := nad.GetNadAnnotation(nads)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.GetName(),
+			Namespace: cr.GetNamespace(),
+		},
+		Spec: corev1.PodSpec{
+			Containers:                    getContainers(cr.GetName(), nc, startupConfig != nil),
+			TerminationGracePeriodSeconds: pointer.Int64(terminationGracePeriods),
+			NodeSelector:                  map[string]string{},
+			Affinity:                      node.GetAffinity(cr.GetNamespace()),
+			Volumes:                       getVolumes(cr.GetName(), nc, startupConfig != nil),
+		},
+	}
+
+	// the nad annotation is part of the hash since the networks of a pod
+	// are only attached when the pod is created
+	var startupConfigData map[string]string
+	if startupConfig != nil {
+		startupConfigData = startupConfig.Data
+	}
+	hashString, err := node.GetHash([]any{d.Spec, string(nadAnnotation), startupConfigData})
+	if err != nil {
+		return nil, err
+	}
+	// the nad annotation is removed by the operator when nads are not enabled
+	d.ObjectMeta.Annotations = map[string]string{
+		invv1alpha1.RevisionHash:     hashString,
+		invv1alpha1.NephioWiringKey:  "true",
+		nadv1.NetworkAttachmentAnnot: string(nadAnnotation),
+	}
+	d.ObjectMeta.Labels = map[string]string{
+		invv1alpha1.NephioTopologyKey: cr.Namespace,
+	}
+	return d, nil
+}
+
+// SetInitialConfig is not applicable, the sample requires no initial config.
+func (r *sample) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	return nil
+}
+
+// GetInitialConfigHash returns an empty hash since the sample requires no initial config.
+func (r *sample) GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error) {
+	return "", nil
+}
+
+// GetDeviceInfo returns no device information.
+func (r *sample) GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error) {
+	return nil, nil
+}
+
+// Teardown is not applicable, the sample has no state outside of the pod.
+func (r *sample) Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	return nil
+}
+
+func getContainers(name string, nc *invv1alpha1.NodeConfig, startupConfig bool) []corev1.Container {
+	volumeMounts := []corev1.VolumeMount{}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      pv.Name,
+			MountPath: pv.MountPath,
+		})
+	}
+	if startupConfig {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      startupConfigVolName,
+			MountPath: startupConfigMountPath,
+			ReadOnly:  true,
+		})
+	}
+	return []corev1.Container{{
+		Name:            name,
+		Image:           nc.GetImage(defaultSampleImageName),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Resources:       nc.GetResourceRequirements(defaultResourceRequests, defaultResourceLimits),
+		SecurityContext: &corev1.SecurityContext{
+			Privileged: pointer.Bool(true),
+		},
+		VolumeMounts: volumeMounts,
+	}}
+}
+
+func getVolumes(name string, nc *invv1alpha1.NodeConfig, startupConfig bool) []corev1.Volume {
+	vols := []corev1.Volume{}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vols = append(vols, corev1.Volume{
+			Name: pv.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: node.GetPersistentVolumeClaimName(name, pv.Name),
+				},
+			},
+		})
+	}
+	if startupConfig {
+		vols = append(vols, corev1.Volume{
+			Name: startupConfigVolName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: node.GetStartupConfigMapName(name),
+					},
+				},
+			},
+		})
+	}
+	return vols
+}
@@ -0,0 +1,206 @@
+package plugin
+
+import (
+	"context"
+	"net"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/plugin/nodepb"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Serve serves the plugin on the listener until the context is done.
+func Serve(ctx context.Context, lis net.Listener, p Plugin) error {
+	s := grpc.NewServer()
+	nodepb.RegisterNodePluginServer(s, NewServer(p))
+	go func() {
+		<-ctx.Done()
+		s.GracefulStop()
+	}()
+	return s.Serve(lis)
+}
+
+// NewServer returns the grpc server of the plugin.
+func NewServer(p Plugin) nodepb.NodePluginServer {
+	return &server{plugin: p}
+}
+
+type server struct {
+	nodepb.UnimplementedNodePluginServer
+	plugin Plugin
+}
+
+func (r *server) GetProvider(ctx context.Context, req *nodepb.GetProviderRequest) (*nodepb.GetProviderResponse, error) {
+	name, providerType := r.plugin.GetProvider(ctx)
+	return &nodepb.GetProviderResponse{Name: name, Type: string(providerType)}, nil
+}
+
+func (r *server) GetNodeConfigDefaults(ctx context.Context, req *nodepb.GetNodeConfigDefaultsRequest) (*nodepb.GetNodeConfigDefaultsResponse, error) {
+	b, err := encode(r.plugin.GetNodeConfigDefaults(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &nodepb.GetNodeConfigDefaultsResponse{NodeConfigSpec: b}, nil
+}
+
+func (r *server) ValidateNodeConfig(ctx context.Context, req *nodepb.ValidateNodeConfigRequest) (*nodepb.ValidateNodeConfigResponse, error) {
+	cr, err := decode[invv1alpha1.Node](req.GetNode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	nc, err := decode[invv1alpha1.NodeConfig](req.GetNodeConfig())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := r.plugin.ValidateNodeConfig(ctx, cr, nc); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &nodepb.ValidateNodeConfigResponse{}, nil
+}
+
+func (r *server) GetNodeModelConfig(ctx context.Context, req *nodepb.GetNodeModelConfigRequest) (*nodepb.GetNodeModelConfigResponse, error) {
+	nc, err := decode[invv1alpha1.NodeConfig](req.GetNodeConfig())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ref := r.plugin.GetNodeModelConfig(ctx, nc)
+	if ref == nil {
+		return nil, status.Error(codes.NotFound, "no node model")
+	}
+	b, err := encode(ref)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &nodepb.GetNodeModelConfigResponse{ObjectReference: b}, nil
+}
+
+func (r *server) GetNetworkAttachmentDefinitions(ctx context.Context, req *nodepb.GetNetworkAttachmentDefinitionsRequest) (*nodepb.GetNetworkAttachmentDefinitionsResponse, error) {
+	cr, err := decode[invv1alpha1.Node](req.GetNode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	nc, err := decode[invv1alpha1.NodeConfig](req.GetNodeConfig())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	nm, err := decode[invv1alpha1.NodeModel](req.GetNodeModel())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	nads, err := r.plugin.GetNetworkAttachmentDefinitions(ctx, cr, nc, nm)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := encodeList(nads)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &nodepb.GetNetworkAttachmentDefinitionsResponse{NetworkAttachmentDefinitions: bs}, nil
+}
+
+func (r *server) GetPersistentVolumeClaims(ctx context.Context, req *nodepb.GetPersistentVolumeClaimsRequest) (*nodepb.GetPersistentVolumeClaimsResponse, error) {
+	cr, err := decode[invv1alpha1.Node](req.GetNode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	nc, err := decode[invv1alpha1.NodeConfig](req.GetNodeConfig())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pvcs, err := r.plugin.GetPersistentVolumeClaims(ctx, cr, nc)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := encodeList(pvcs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &nodepb.GetPersistentVolumeClaimsResponse{PersistentVolumeClaims: bs}, nil
+}
+
+func (r *server) GetPodSpec(ctx context.Context, req *nodepb.GetPodSpecRequest) (*nodepb.GetPodSpecResponse, error) {
+	cr, err := decode[invv1alpha1.Node](req.GetNode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	nc, err := decode[invv1alpha1.NodeConfig](req.GetNodeConfig())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	nads, err := decodeList[nadv1.NetworkAttachmentDefinition](req.GetNetworkAttachmentDefinitions())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var startupConfig *corev1.ConfigMap
+	if len(req.GetStartupConfig()) > 0 {
+		if startupConfig, err = decode[corev1.ConfigMap](req.GetStartupConfig()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	pod, err := r.plugin.GetPodSpec(ctx, cr, nc, nads, startupConfig)
+	if err != nil {
+		return nil, err
+	}
+	b, err := encode(pod)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &nodepb.GetPodSpecResponse{Pod: b}, nil
+}
+
+func (r *server) SetInitialConfig(ctx context.Context, req *nodepb.SetInitialConfigRequest) (*nodepb.SetInitialConfigResponse, error) {
+	cr, err := decode[invv1alpha1.Node](req.GetNode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := r.plugin.SetInitialConfig(ctx, cr, decodePodIPs(req.GetPodIps())); err != nil {
+		return nil, err
+	}
+	return &nodepb.SetInitialConfigResponse{}, nil
+}
+
+func (r *server) GetInitialConfigHash(ctx context.Context, req *nodepb.GetInitialConfigHashRequest) (*nodepb.GetInitialConfigHashResponse, error) {
+	cr, err := decode[invv1alpha1.Node](req.GetNode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	hash, err := r.plugin.GetInitialConfigHash(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	return &nodepb.GetInitialConfigHashResponse{Hash: hash}, nil
+}
+
+func (r *server) GetDeviceInfo(ctx context.Context, req *nodepb.GetDeviceInfoRequest) (*nodepb.GetDeviceInfoResponse, error) {
+	cr, err := decode[invv1alpha1.Node](req.GetNode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	info, err := r.plugin.GetDeviceInfo(ctx, cr, decodePodIPs(req.GetPodIps()))
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return &nodepb.GetDeviceInfoResponse{}, nil
+	}
+	return &nodepb.GetDeviceInfoResponse{
+		Found:           true,
+		SoftwareVersion: info.SoftwareVersion,
+		Chassis:         info.Chassis,
+	}, nil
+}
+
+func (r *server) Teardown(ctx context.Context, req *nodepb.TeardownRequest) (*nodepb.TeardownResponse, error) {
+	cr, err := decode[invv1alpha1.Node](req.GetNode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := r.plugin.Teardown(ctx, cr, decodePodIPs(req.GetPodIps())); err != nil {
+		return nil, err
+	}
+	return &nodepb.TeardownResponse{}, nil
+}
@@ -0,0 +1,46 @@
+package node
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const podAffinityWeight = 100
+
+// GetAffinity returns the affinity of the pods of the nodes that spreads the nodes
+// of a topology over the hosts of the cluster.
+func GetAffinity(topology string) *corev1.Affinity {
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: podAffinityWeight,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{
+								Key:      invv1alpha1.NephioTopologyKey,
+								Operator: "In",
+								Values:   []string{topology},
+							}},
+						},
+						TopologyKey: "kubernetes.io/hostname",
+					},
+				},
+			},
+		},
+	}
+}
+
+// GetHash returns the sha256 hash of the json encoding of x.
+func GetHash(x any) (string, error) {
+	b, err := json.Marshal(x)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
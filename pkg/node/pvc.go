@@ -0,0 +1,100 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// GetPersistentVolumeClaimName returns the name of the persistent volume claim
+// of a persistent volume of the node.
+func GetPersistentVolumeClaimName(nodeName, pvName string) string {
+	return strings.Join([]string{nodeName, pvName}, "-")
+}
+
+// PersistentVolumesKey is the annotation of a node config that defines the storage of
+// the persistent volumes of the node config, the value is a json object of storage keyed
+// by the name of the persistent volume, e.g.
+// {"data": {"storageClassName": "fast", "accessModes": ["ReadWriteMany"]}}
+const PersistentVolumesKey = "node.nephio.com/persistent-volumes"
+
+// PersistentVolumeStorage defines the storage of a persistent volume of a node config.
+type PersistentVolumeStorage struct {
+	// StorageClassName of the persistent volume claim, when empty the STORAGE_CLASS
+	// environment variable or else the default storage class of the cluster is used
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// AccessModes of the persistent volume claim, when empty ReadWriteOnce is used
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+}
+
+// GetPersistentVolumeStorage returns the storage of the persistent volumes of the node
+// config keyed by the name of the persistent volume.
+func GetPersistentVolumeStorage(nc *invv1alpha1.NodeConfig) (map[string]PersistentVolumeStorage, error) {
+	storage := map[string]PersistentVolumeStorage{}
+	v, ok := nc.GetAnnotations()[PersistentVolumesKey]
+	if !ok {
+		return storage, nil
+	}
+	if err := json.Unmarshal([]byte(v), &storage); err != nil {
+		return nil, fmt.Errorf("cannot parse persistent volumes of node config %s, err: %w", nc.GetName(), err)
+	}
+	return storage, nil
+}
+
+// GetPersistentVolumeClaims returns a persistent volume claim for every persistent
+// volume of the node config. The storage class and the access modes are provided by
+// the PersistentVolumesKey annotation of the node config. The storage class falls back
+// to the STORAGE_CLASS environment variable, when not set the default storage class of
+// the cluster is used.
+func GetPersistentVolumeClaims(cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, s *runtime.Scheme) ([]*corev1.PersistentVolumeClaim, error) {
+	storage, err := GetPersistentVolumeStorage(nc)
+	if err != nil {
+		return nil, err
+	}
+	pvcs := []*corev1.PersistentVolumeClaim{}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		accessModes := storage[pv.Name].AccessModes
+		if len(accessModes) == 0 {
+			accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+		}
+		pvc := &corev1.PersistentVolumeClaim{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: corev1.SchemeGroupVersion.Identifier(),
+				Kind:       reflect.TypeOf(corev1.PersistentVolumeClaim{}).Name(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GetPersistentVolumeClaimName(cr.GetName(), pv.Name),
+				Namespace: cr.GetNamespace(),
+				Labels: map[string]string{
+					invv1alpha1.NephioNodeNameKey: cr.GetName(),
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: accessModes,
+				Resources: corev1.ResourceRequirements{
+					Requests: pv.Requests,
+				},
+			},
+		}
+		storageClass := storage[pv.Name].StorageClassName
+		if storageClass == "" {
+			storageClass = os.Getenv("STORAGE_CLASS")
+		}
+		if storageClass != "" {
+			pvc.Spec.StorageClassName = &storageClass
+		}
+		if err := ctrl.SetControllerReference(cr, pvc, s); err != nil {
+			return nil, err
+		}
+		pvcs = append(pvcs, pvc)
+	}
+	return pvcs, nil
+}
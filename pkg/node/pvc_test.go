@@ -0,0 +1,90 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+)
+
+func TestGetPersistentVolumeClaims(t *testing.T) {
+	cases := map[string]struct {
+		storageClassEnv  string
+		annotations      map[string]string
+		wantStorageClass *string
+		wantAccessModes  []corev1.PersistentVolumeAccessMode
+		wantErr          bool
+	}{
+		"Defaults": {
+			wantAccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		},
+		"EnvFallback": {
+			storageClassEnv:  "standard",
+			wantStorageClass: pointer.String("standard"),
+			wantAccessModes:  []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		},
+		"NodeConfig": {
+			storageClassEnv: "standard",
+			annotations: map[string]string{
+				PersistentVolumesKey: `{"data":{"storageClassName":"fast","accessModes":["ReadWriteMany"]}}`,
+			},
+			wantStorageClass: pointer.String("fast"),
+			wantAccessModes:  []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+		},
+		"OtherVolume": {
+			storageClassEnv: "standard",
+			annotations: map[string]string{
+				PersistentVolumesKey: `{"logs":{"storageClassName":"fast"}}`,
+			},
+			wantStorageClass: pointer.String("standard"),
+			wantAccessModes:  []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		},
+		"Invalid": {
+			annotations: map[string]string{
+				PersistentVolumesKey: `{"data":"fast"}`,
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("STORAGE_CLASS", tc.storageClassEnv)
+			s := runtime.NewScheme()
+			if err := invv1alpha1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			cr := &invv1alpha1.Node{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "node1"}}
+			nc := &invv1alpha1.NodeConfig{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec: invv1alpha1.NodeConfigSpec{
+					PersistentVolumes: []invv1alpha1.PersistentVolume{{Name: "data", MountPath: "/data"}},
+				},
+			}
+
+			pvcs, err := GetPersistentVolumeClaims(cr, nc, s)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("want error, got: nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(pvcs) != 1 {
+				t.Fatalf("want 1 pvc, got: %d", len(pvcs))
+			}
+			if diff := cmp.Diff(tc.wantStorageClass, pvcs[0].Spec.StorageClassName); diff != "" {
+				t.Errorf("storage class -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantAccessModes, pvcs[0].Spec.AccessModes); diff != "" {
+				t.Errorf("access modes -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
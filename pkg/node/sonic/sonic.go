@@ -0,0 +1,437 @@
+package sonic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SONiCVSProvider       = "sonic-vs.sonic-net.org"
+	defaultSONiCImageName = "netreplica/docker-sonic-vs:latest"
+	defaultSONiCModel     = "vs"
+	defaultHwSku          = "Force10-S6000"
+	defaultPlatform       = "x86_64-kvm_x86_64-r0"
+	defaultInterfaceMTU   = 9100
+	defaultInterfaceSpeed = "100000"
+	portPfx               = "Ethernet"
+	containerInterfacePfx = "eth"
+	lanesPerInterface     = 4
+
+	//
+	terminationGracePeriodSeconds = 0
+	readinessInitialDelay         = 20
+	readinessPeriodSeconds        = 5
+	readinessFailureThreshold     = 30
+
+	// volumes
+	startupConfigVolName = "startup-config"
+	configDBKey          = "config_db.json"
+	configDBMntPath      = "/etc/sonic/config_db.json"
+	laneMapKey           = "lanemap.ini"
+	laneMapMntPath       = "/usr/share/sonic/hwsku/lanemap.ini"
+)
+
+var (
+	//nolint:gochecknoglobals
+	defaultCmd = []string{
+		"/usr/local/bin/supervisord",
+	}
+
+	// readinessPrograms are syncd and the swss daemons that program the ports
+	//nolint:gochecknoglobals
+	readinessPrograms = []string{"syncd", "orchagent", "portsyncd"}
+
+	// readinessCmd checks that every readiness program is listed as running, the
+	// check fails when supervisorctl fails, e.g. while supervisord is starting
+	//nolint:gochecknoglobals
+	readinessCmd = []string{
+		"sh",
+		"-c",
+		fmt.Sprintf(`out=$(supervisorctl status %s) || exit 1; [ "$(echo "$out" | grep -c ' RUNNING ')" -eq %d ]`,
+			strings.Join(readinessPrograms, " "), len(readinessPrograms)),
+	}
+
+	//nolint:gochecknoglobals
+	defaultResourceRequests = map[string]string{
+		"cpu":    "0.5",
+		"memory": "1Gi",
+	}
+	defaultResourceLimits = map[string]string{}
+)
+
+// Register registers the node in the NodeRegistry.
+func Register(r node.NodeRegistry) {
+	r.Register(SONiCVSProvider, func(c client.Client, s *runtime.Scheme) node.Node {
+		return &sonic{
+			Client: c,
+			scheme: s,
+		}
+	})
+}
+
+type sonic struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+func (r *sonic) GetProviderType(ctx context.Context) node.ProviderType {
+	return node.ProviderTypeNetwork
+}
+
+func (r *sonic) GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec {
+	return &invv1alpha1.NodeConfigSpec{
+		Provider: SONiCVSProvider,
+		Model:    pointer.String(defaultSONiCModel),
+		Image:    pointer.String(defaultSONiCImageName),
+	}
+}
+
+// ValidateNodeConfig validates that the node model of the model exists, since the
+// virtual switch has no variants.
+func (r *sonic) ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error {
+	if nc.Spec.Model == nil {
+		return nil
+	}
+	if _, err := r.GetNodeModel(ctx, nc); err != nil {
+		return fmt.Errorf("node model %s/%s of model %q not found, create it or use another model",
+			os.Getenv("POD_NAMESPACE"), getNodeModelName(nc), *nc.Spec.Model)
+	}
+	return nil
+}
+
+func (r *sonic) GetNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
+	// get nodeConfig by merging the provider defaults with the node config layers
+	return node.GetNodeConfig(ctx, r.Client, cr, r.GetNodeConfigDefaults(ctx))
+}
+
+func (r *sonic) GetNodeModelConfig(ctx context.Context, nc *invv1alpha1.NodeConfig) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: invv1alpha1.NodeKindAPIVersion,
+		Kind:       invv1alpha1.NodeModelKind,
+		Name:       getNodeModelName(nc),
+		Namespace:  os.Getenv("POD_NAMESPACE"),
+	}
+}
+
+func (r *sonic) GetNodeModel(ctx context.Context, nc *invv1alpha1.NodeConfig) (*invv1alpha1.NodeModel, error) {
+	nm := &invv1alpha1.NodeModel{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      getNodeModelName(nc),
+		Namespace: os.Getenv("POD_NAMESPACE"),
+	}, nm); err != nil {
+		return nil, err
+	}
+	return nm, nil
+}
+
+// GetNetworkAttachmentDefinitions returns a nad for every interface of the node model,
+// the interfaces of the node model are named ethN in the container in the order of
+// the node model, see getPortName for the name of the port of the interface.
+func (r *sonic) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultSONiCModel), err)
+	}
+	itfces := make([]node.Interface, 0, len(nm.Spec.Interfaces))
+	for i, itfce := range nm.Spec.Interfaces {
+		itfces = append(itfces, node.Interface{
+			Name:          itfce.Name,
+			ContainerName: getContainerInterfaceName(i),
+			MTU:           defaultInterfaceMTU,
+		})
+	}
+	return node.GetNetworkAttachmentDefinitions(cr, nc, itfces, r.scheme)
+}
+
+func (r *sonic) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
+	return node.GetPersistentVolumeClaims(cr, nc, r.scheme)
+}
+
+// GetStartupConfig returns the config map with the config_db.json and the lane map of
+// the node. The config_db.json is rendered from the node model, unless it is provided
+// by the startup config templates referenced in the node config. The lane map maps the
+// ethN interfaces of the container to the lanes of the ports in the config_db.json.
+func (r *sonic) GetStartupConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) (*corev1.ConfigMap, error) {
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultSONiCModel), err)
+	}
+	data := map[string]string{}
+	if nc.Spec.StartupConfig != nil {
+		cm, err := node.GetStartupConfig(ctx, r.Client, cr, nc, nm, r.scheme)
+		if err != nil {
+			return nil, err
+		}
+		data = cm.Data
+	}
+	if _, ok := data[configDBKey]; !ok {
+		configDB, err := getConfigDB(cr.GetName(), nm)
+		if err != nil {
+			return nil, err
+		}
+		data[configDBKey] = configDB
+	}
+	data[laneMapKey] = getLaneMap(nm)
+	return node.NewStartupConfigMap(cr, data, r.scheme)
+}
+
+func (r *sonic) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*corev1.Pod, error) {
+	nadAnnotation, err := nad.GetNadAnnotation(nads)
+	if err != nil {
+		return nil, err
+	}
+
+	startupConfig, err := r.GetStartupConfig(ctx, cr, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.GetName(),
+			Namespace: cr.GetNamespace(),
+		},
+		Spec: corev1.PodSpec{
+			Containers:                    getContainers(cr.GetName(), nc),
+			TerminationGracePeriodSeconds: pointer.Int64(terminationGracePeriodSeconds),
+			NodeSelector:                  map[string]string{},
+			Affinity:                      node.GetAffinity(cr.GetNamespace()),
+			Volumes:                       getVolumes(cr.GetName(), nc),
+		},
+	}
+
+	// the nad annotation is part of the hash since the networks of a pod
+	// are only attached when the pod is created, the startup config data
+	// is part of the hash since it is only read when the pod starts
+	hashString, err := node.GetHash([]any{d.Spec, string(nadAnnotation), startupConfig.Data})
+	if err != nil {
+		return nil, err
+	}
+	if len(d.GetAnnotations()) == 0 {
+		d.ObjectMeta.Annotations = map[string]string{}
+	}
+	d.ObjectMeta.Annotations[invv1alpha1.RevisionHash] = hashString
+	d.ObjectMeta.Annotations[invv1alpha1.NephioWiringKey] = "true"
+	if os.Getenv("ENABLE_NAD") == "true" {
+		d.ObjectMeta.Annotations[nadv1.NetworkAttachmentAnnot] = string(nadAnnotation)
+	}
+
+	if len(d.GetLabels()) == 0 {
+		d.ObjectMeta.Labels = map[string]string{}
+	}
+	d.ObjectMeta.Labels[invv1alpha1.NephioTopologyKey] = cr.Namespace
+
+	if err := ctrl.SetControllerReference(cr, d, r.scheme); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// SetInitialConfig is not applicable, the config of the node is delivered through
+// the config_db.json of the startup config.
+func (r *sonic) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	return nil
+}
+
+// GetInitialConfigHash returns an empty hash since the node has no initial config.
+func (r *sonic) GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error) {
+	return "", nil
+}
+
+// GetDeviceInfo returns no device information since the node is not bootstrapped.
+func (r *sonic) GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error) {
+	return nil, nil
+}
+
+// Teardown is not applicable, the pod and its resources are deleted by the controller.
+func (r *sonic) Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	return nil
+}
+
+func getNodeModelName(nc *invv1alpha1.NodeConfig) string {
+	return fmt.Sprintf("%s-%s", SONiCVSProvider, nc.GetModel(defaultSONiCModel))
+}
+
+// getContainerInterfaceName returns the name of the interface in the container,
+// eth0 is the management interface so the front panel ports start at eth1.
+func getContainerInterfaceName(idx int) string {
+	return fmt.Sprintf("%s%d", containerInterfacePfx, idx+1)
+}
+
+// getPortName returns the name of the port of the interface with the index in the
+// node model, the ports of the hwsku are named Ethernet<first lane - 1>.
+func getPortName(idx int) string {
+	return fmt.Sprintf("%s%d", portPfx, idx*lanesPerInterface)
+}
+
+// getPortSpeed returns the speed of the port in Mbps from the speed of the interface
+// in the node model, e.g. 100G or 100000. An empty speed uses the default speed.
+func getPortSpeed(speed string) (string, error) {
+	if speed == "" {
+		return defaultInterfaceSpeed, nil
+	}
+	multiplier := 1
+	value := speed
+	switch {
+	case strings.HasSuffix(speed, "G"):
+		multiplier = 1000
+		value = strings.TrimSuffix(speed, "G")
+	case strings.HasSuffix(speed, "M"):
+		value = strings.TrimSuffix(speed, "M")
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return "", fmt.Errorf("invalid speed %q, want a speed in Mbps or with a M or G suffix, e.g. 100G", speed)
+	}
+	return strconv.Itoa(n * multiplier), nil
+}
+
+// getLanes returns the lanes of the port of the interface with the index in the node model.
+func getLanes(idx int) []string {
+	lanes := make([]string, 0, lanesPerInterface)
+	for l := 1; l <= lanesPerInterface; l++ {
+		lanes = append(lanes, strconv.Itoa(idx*lanesPerInterface+l))
+	}
+	return lanes
+}
+
+// getConfigDB returns the config_db.json of the node with the device metadata and
+// a port for every interface of the node model. The port is named after its index
+// in the node model and is aliased to the name of the interface in the node model.
+func getConfigDB(name string, nm *invv1alpha1.NodeModel) (string, error) {
+	ports := make(map[string]map[string]string, len(nm.Spec.Interfaces))
+	for i, itfce := range nm.Spec.Interfaces {
+		speed, err := getPortSpeed(itfce.Speed)
+		if err != nil {
+			return "", fmt.Errorf("cannot render config_db.json, interface %s, err: %w", itfce.Name, err)
+		}
+		ports[getPortName(i)] = map[string]string{
+			"admin_status": "up",
+			"alias":        itfce.Name,
+			"index":        strconv.Itoa(i),
+			"lanes":        strings.Join(getLanes(i), ","),
+			"mtu":          strconv.Itoa(defaultInterfaceMTU),
+			"speed":        speed,
+		}
+	}
+	b, err := json.MarshalIndent(map[string]any{
+		"DEVICE_METADATA": map[string]any{
+			"localhost": map[string]string{
+				"hostname": name,
+				"hwsku":    defaultHwSku,
+				"platform": defaultPlatform,
+				"type":     "LeafRouter",
+			},
+		},
+		"PORT": ports,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// getLaneMap returns the lane map of the virtual switch that maps the ethN interfaces
+// of the container to the lanes of the ports.
+func getLaneMap(nm *invv1alpha1.NodeModel) string {
+	var sb strings.Builder
+	for i := range nm.Spec.Interfaces {
+		fmt.Fprintf(&sb, "%s:%s\n", getContainerInterfaceName(i), strings.Join(getLanes(i), ","))
+	}
+	return sb.String()
+}
+
+func getContainers(name string, nc *invv1alpha1.NodeConfig) []corev1.Container {
+	return []corev1.Container{{
+		Name:            name,
+		Image:           nc.GetImage(defaultSONiCImageName),
+		Command:         defaultCmd,
+		Resources:       nc.GetResourceRequirements(defaultResourceRequests, defaultResourceLimits),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &corev1.SecurityContext{
+			Privileged: pointer.Bool(true),
+			RunAsUser:  pointer.Int64(0),
+		},
+		VolumeMounts: getVolumeMounts(nc),
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				Exec: &corev1.ExecAction{
+					Command: readinessCmd,
+				},
+			},
+			InitialDelaySeconds: readinessInitialDelay,
+			PeriodSeconds:       readinessPeriodSeconds,
+			FailureThreshold:    readinessFailureThreshold,
+		},
+	}}
+}
+
+func getVolumes(name string, nc *invv1alpha1.NodeConfig) []corev1.Volume {
+	vols := []corev1.Volume{
+		{
+			Name: startupConfigVolName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: node.GetStartupConfigMapName(name),
+					},
+				},
+			},
+		},
+	}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vols = append(vols, corev1.Volume{
+			Name: pv.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: node.GetPersistentVolumeClaimName(name, pv.Name),
+				},
+			},
+		})
+	}
+	return vols
+}
+
+// getVolumeMounts mounts the config_db.json and the lane map of the startup config
+// as files, such that the other files in the directories of the image are preserved.
+// Since the config_db.json is read only, the config of the node is owned by the
+// startup config and config save is not supported.
+func getVolumeMounts(nc *invv1alpha1.NodeConfig) []corev1.VolumeMount {
+	vms := []corev1.VolumeMount{
+		{
+			Name:      startupConfigVolName,
+			MountPath: configDBMntPath,
+			SubPath:   configDBKey,
+			ReadOnly:  true,
+		},
+		{
+			Name:      startupConfigVolName,
+			MountPath: laneMapMntPath,
+			SubPath:   laneMapKey,
+			ReadOnly:  true,
+		},
+	}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vms = append(vms, corev1.VolumeMount{
+			Name:      pv.Name,
+			MountPath: pv.MountPath,
+		})
+	}
+	return vms
+}
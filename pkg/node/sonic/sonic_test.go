@@ -0,0 +1,131 @@
+package sonic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+)
+
+func TestGetPortSpeed(t *testing.T) {
+	cases := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"Default": {
+			want: defaultInterfaceSpeed,
+		},
+		"Mbps": {
+			input: "40000",
+			want:  "40000",
+		},
+		"Gbps": {
+			input: "25G",
+			want:  "25000",
+		},
+		"MbpsSuffix": {
+			input: "100M",
+			want:  "100",
+		},
+		"Invalid": {
+			input:   "fast",
+			wantErr: true,
+		},
+		"Zero": {
+			input:   "0G",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := getPortSpeed(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("want error, got: %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetConfigDB(t *testing.T) {
+	cases := map[string]struct {
+		input   []invv1alpha1.NodeModelInterface
+		want    map[string]map[string]string
+		wantErr bool
+	}{
+		"Ports": {
+			input: []invv1alpha1.NodeModelInterface{
+				{Name: "e1-1", Speed: "40G"},
+				{Name: "e1-2"},
+			},
+			want: map[string]map[string]string{
+				"Ethernet0": {
+					"admin_status": "up",
+					"alias":        "e1-1",
+					"index":        "0",
+					"lanes":        "1,2,3,4",
+					"mtu":          "9100",
+					"speed":        "40000",
+				},
+				"Ethernet4": {
+					"admin_status": "up",
+					"alias":        "e1-2",
+					"index":        "1",
+					"lanes":        "5,6,7,8",
+					"mtu":          "9100",
+					"speed":        "100000",
+				},
+			},
+		},
+		"InvalidSpeed": {
+			input:   []invv1alpha1.NodeModelInterface{{Name: "e1-1", Speed: "fast"}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nm := &invv1alpha1.NodeModel{Spec: invv1alpha1.NodeModelSpec{Interfaces: tc.input}}
+			got, err := getConfigDB("leaf1", nm)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("want error, got: %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			configDB := struct {
+				Port map[string]map[string]string `json:"PORT"`
+			}{}
+			if err := json.Unmarshal([]byte(got), &configDB); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, configDB.Port); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetLaneMap(t *testing.T) {
+	nm := &invv1alpha1.NodeModel{Spec: invv1alpha1.NodeModelSpec{
+		Interfaces: []invv1alpha1.NodeModelInterface{{Name: "e1-1"}, {Name: "e1-2"}},
+	}}
+	want := "eth1:1,2,3,4\neth2:5,6,7,8\n"
+	if diff := cmp.Diff(want, getLaneMap(nm)); diff != "" {
+		t.Errorf("-want, +got:\n%s", diff)
+	}
+}
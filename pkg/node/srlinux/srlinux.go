@@ -1,17 +1,15 @@
 package srlinux
 
 import (
-	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
-	"log"
+	"net"
 	"os"
-	"reflect"
-	"strings"
 	"time"
 
+	"github.com/henderiw-nephio/network-node-operator/pkg/bootstrap"
 	"github.com/henderiw-nephio/network-node-operator/pkg/cert"
 	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
 	"github.com/henderiw-nephio/network-node-operator/pkg/node"
@@ -28,6 +26,7 @@ import (
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
@@ -36,6 +35,10 @@ const (
 	defaultSRLinuxImageName = "ghcr.io/nokia/srlinux:latest"
 	defaultSRLinuxVariant   = "ixrd3l"
 	scrapliGoSRLinuxKey     = "nokia_srl"
+	defaultInterfaceMTU     = 9500
+	gnmiPort                = "57400"
+	softwareVersionPath     = "/system/information/version"
+	chassisTypePath         = "/platform/chassis/type"
 
 	//
 	terminationGracePeriodSeconds = 0
@@ -46,14 +49,13 @@ const (
 	podAffinityWeight             = 100
 
 	// volumes
-	//initialConfigVolMntPath  = "/tmp/initial-config"
-	//initialConfigCfgMapName  = "srlinux-initial-config"
+	startupConfigVolMntPath  = "/tmp/initial-config"
+	startupConfigVolName     = "startup-config"
 	defaultSecretUserNameKey = "username"
 	defaultSecretPasswordKey = "password"
 	certificateProfileName   = "k8s-profile"
 	//certificateVolName         = "serving-cert"
 	//certificateVolMntPath      = "serving-certs"
-	//initialConfigVolName       = "initial-config-volume"
 	variantsVolName            = "variants"
 	variantsVolMntPath         = "/tmp/topo"
 	variantsTemplateTempName   = "topo-template.yml"
@@ -140,15 +142,37 @@ type srl struct {
 
 func (r *srl) GetProviderType(ctx context.Context) node.ProviderType { return node.ProviderTypeNetwork }
 
+func (r *srl) GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec {
+	return &invv1alpha1.NodeConfigSpec{
+		Provider: NokiaSRLinuxProvider,
+		Model:    pointer.String(defaultSRLinuxVariant),
+		Image:    pointer.String(defaultSRLinuxImageName),
+	}
+}
+
+// ValidateNodeConfig validates the model against the variants and the license key
+// against the licenses in the namespace of the node.
+func (r *srl) ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error {
+	if nc.Spec.Model != nil {
+		if err := node.ValidateVariant(ctx, r.Client, cr.GetNamespace(), variantsCfgMapName, *nc.Spec.Model); err != nil {
+			return err
+		}
+	}
+	if nc.Spec.LicenseKey != nil {
+		return node.ValidateLicenseKey(ctx, r.Client, types.NamespacedName{Namespace: cr.GetNamespace(), Name: licenseCfgMapName}, *nc.Spec.LicenseKey)
+	}
+	return nil
+}
+
 func (r *srl) GetNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
-	// get nodeConfig via paramRef
-	nodeConfig, err := r.getNodeConfig(ctx, cr)
+	// get nodeConfig by merging the provider defaults with the node config layers
+	nodeConfig, err := node.GetNodeConfig(ctx, r.Client, cr, r.GetNodeConfigDefaults(ctx))
 	if err != nil {
 		return nil, err
 	}
 
 	// validate if the model returned exists in the variant list
-	if err := r.checkVariants(ctx, cr, nodeConfig.GetModel(defaultSRLinuxVariant)); err != nil {
+	if _, err := r.getVariant(ctx, cr, nodeConfig.GetModel(defaultSRLinuxVariant)); err != nil {
 		return nil, err
 	}
 	return nodeConfig, nil
@@ -174,42 +198,29 @@ func (r *srl) GetNodeModel(ctx context.Context, nc *invv1alpha1.NodeConfig) (*in
 	return nm, nil
 }
 
+// GetNetworkAttachmentDefinitions returns a nad for every interface of the node model,
+// with the mtu of the interface in the node model or else the default mtu.
 func (r *srl) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
-	// todo check node model and get interfaces from the model
-	nads := []*nadv1.NetworkAttachmentDefinition{}
-	ifNames := []string{"e1-1", "e1-2"}
-	for _, ifName := range ifNames {
-		b, err := nad.GetNadConfig([]nad.PluginConfigInterface{
-			nad.WirePlugin{
-				PluginCniType: nad.PluginCniType{
-					Type: nad.WirePluginType,
-				},
-				InterfaceName: ifName,
-			},
-		})
-		if err != nil {
-			return nil, err
-		}
-
-		n := &nadv1.NetworkAttachmentDefinition{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: nadv1.SchemeGroupVersion.Identifier(),
-				Kind:       reflect.TypeOf(nadv1.NetworkAttachmentDefinition{}).Name(),
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: cr.GetNamespace(),
-				Name:      strings.Join([]string{cr.GetName(), ifName}, "-"),
-			},
-			Spec: nadv1.NetworkAttachmentDefinitionSpec{
-				Config: string(b),
-			},
-		}
-		if err := ctrl.SetControllerReference(cr, n, r.scheme); err != nil {
-			return nil, err
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultSRLinuxVariant), err)
+	}
+	mtus, err := node.GetInterfaceMTUs(nm)
+	if err != nil {
+		return nil, err
+	}
+	itfces := make([]node.Interface, 0, len(nm.Spec.Interfaces))
+	for _, itfce := range nm.Spec.Interfaces {
+		mtu, ok := mtus[itfce.Name]
+		if !ok {
+			mtu = defaultInterfaceMTU
 		}
-		nads = append(nads, n)
+		itfces = append(itfces, node.Interface{
+			Name: itfce.Name,
+			MTU:  mtu,
+		})
 	}
-	return nads, nil
+	return node.GetNetworkAttachmentDefinitions(cr, nc, itfces, r.scheme)
 }
 
 func (r *srl) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
@@ -218,12 +229,40 @@ func (r *srl) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Nod
 	return pvcs, nil
 }
 
+// GetStartupConfig returns the config map with the startup config of the node rendered
+// from the startup config templates referenced in the node config.
+func (r *srl) GetStartupConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) (*corev1.ConfigMap, error) {
+	if nc.Spec.StartupConfig == nil {
+		return nil, nil
+	}
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultSRLinuxVariant), err)
+	}
+	return node.GetStartupConfig(ctx, r.Client, cr, nc, nm, r.scheme)
+}
+
 func (r *srl) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*corev1.Pod, error) {
 	nadAnnotation, err := nad.GetNadAnnotation(nads)
 	if err != nil {
 		return nil, err
 	}
 
+	startupConfig, err := r.GetStartupConfig(ctx, cr, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := r.getVariant(ctx, cr, nc.GetModel(defaultSRLinuxVariant))
+	if err != nil {
+		return nil, err
+	}
+
+	license, err := r.getLicense(ctx, cr, nc)
+	if err != nil {
+		return nil, err
+	}
+
 	d := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.GetName(),
@@ -239,7 +278,11 @@ func (r *srl) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alp
 		},
 	}
 
-	hashString := getHash(d.Spec)
+	// the nad annotation is part of the hash since the networks of a pod
+	// are only attached when the pod is created, the startup config data,
+	// the variant and the license are part of the hash since they are only
+	// read when the pod starts
+	hashString := getHash([]any{d.Spec, string(nadAnnotation), getStartupConfigData(startupConfig), variant, license})
 	if len(d.GetAnnotations()) == 0 {
 		d.ObjectMeta.Annotations = map[string]string{}
 	}
@@ -261,39 +304,207 @@ func (r *srl) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alp
 }
 
 func (r *srl) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	username, password, err := r.getCredentials(ctx, cr)
+	if err != nil {
+		return err
+	}
+	certData, err := r.getCertData(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	// the initial config is pushed with gnmi set, before the tls profile is provisioned
+	// the gnmi server uses the self signed certificate of the factory config.
+	// the cli is used as a fallback
+	t := bootstrap.NewFallbackTransport(
+		bootstrap.NewGNMITransport(bootstrap.GNMIConfig{
+			Address:    net.JoinHostPort(ips[0].IP, gnmiPort),
+			Username:   username,
+			Password:   password,
+			SkipVerify: true,
+		}),
+		&cliTransport{
+			address:  ips[0].IP,
+			username: username,
+			password: password,
+			certData: certData,
+		},
+	)
+	return t.Push(ctx, getInitialConfig(certData))
+}
+
+// GetInitialConfigHash returns the hash of the initial config, which includes the
+// certificate data, such that a rotation of the certificate changes the hash.
+func (r *srl) GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error) {
+	certData, err := r.getCertData(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+	return getHash(getInitialConfig(certData)), nil
+}
+
+// GetDeviceInfo reads the software version and the chassis type from the node using gnmi.
+func (r *srl) GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error) {
+	username, password, err := r.getCredentials(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	values, err := bootstrap.GetValues(ctx, bootstrap.GNMIConfig{
+		Address:    net.JoinHostPort(ips[0].IP, gnmiPort),
+		Username:   username,
+		Password:   password,
+		SkipVerify: true,
+	}, softwareVersionPath, chassisTypePath)
+	if err != nil {
+		return nil, err
+	}
+	return &node.DeviceInfo{
+		SoftwareVersion: values[softwareVersionPath],
+		Chassis:         values[chassisTypePath],
+	}, nil
+}
+
+// Teardown saves the running config to the startup config of the node before the node
+// is deleted. The license is mounted from a shared config map, so there is no license
+// allocation to release.
+func (r *srl) Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	username, password, err := r.getCredentials(ctx, cr)
+	if err != nil {
+		return err
+	}
+	return bootstrap.NewGNMITransport(bootstrap.GNMIConfig{
+		Address:    net.JoinHostPort(ips[0].IP, gnmiPort),
+		Username:   username,
+		Password:   password,
+		SkipVerify: true,
+	}).Push(ctx, &bootstrap.Config{Tools: getSaveTools()})
+}
+
+func (r *srl) getCredentials(ctx context.Context, cr *invv1alpha1.Node) (string, string, error) {
 	secret := &corev1.Secret{}
 	// we assume right now the default secret name is equal to the provider
 	// this provider username and password
 	if err := r.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: NokiaSRLinuxProvider}, secret); err != nil {
-		return err
+		return "", "", err
 	}
+	return string(secret.Data[defaultSecretUserNameKey]), string(secret.Data[defaultSecretPasswordKey]), nil
+}
 
+func (r *srl) getCertData(ctx context.Context, cr *invv1alpha1.Node) (*cert.CertData, error) {
 	certSecret := &corev1.Secret{}
 	// this is used to provide certificate for the gnmi/gnsi/etc servers on the device
 	if err := r.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}, certSecret); err != nil {
-		return err
+		return nil, err
 	}
+	return cert.GetCertificateData(certSecret, certificateProfileName)
+}
 
-	certData, err := cert.GetCertificateData(certSecret, certificateProfileName)
-	if err != nil {
-		return err
+// getInitialConfig returns the initial config of the node as structured data.
+func getInitialConfig(certData *cert.CertData) *bootstrap.Config {
+	return &bootstrap.Config{
+		Updates: []bootstrap.Update{
+			{
+				Path: fmt.Sprintf("/system/tls/server-profile[name=%s]", certData.ProfileName),
+				Value: map[string]any{
+					"key":                 certData.Key,
+					"certificate":         certData.Cert,
+					"trust-anchor":        certData.CA,
+					"authenticate-client": false,
+				},
+			},
+			{
+				Path:  "/system/lldp",
+				Value: map[string]any{"admin-state": "enable"},
+			},
+			{
+				Path: "/system/gnmi-server",
+				Value: map[string]any{
+					"admin-state":   "enable",
+					"rate-limit":    65000,
+					"trace-options": []string{"common", "request", "response"},
+					"unix-socket":   map[string]any{"admin-state": "enable"},
+					"network-instance": []map[string]any{{
+						"name":        "mgmt",
+						"admin-state": "enable",
+						"tls-profile": certData.ProfileName,
+					}},
+				},
+			},
+			{
+				Path: "/system/gribi-server",
+				Value: map[string]any{
+					"admin-state": "enable",
+					"network-instance": []map[string]any{{
+						"name":        "mgmt",
+						"admin-state": "enable",
+						"tls-profile": certData.ProfileName,
+					}},
+				},
+			},
+			{
+				Path: "/system/json-rpc-server",
+				Value: map[string]any{
+					"admin-state": "enable",
+					"network-instance": []map[string]any{{
+						"name": "mgmt",
+						"http": map[string]any{"admin-state": "enable"},
+						"https": map[string]any{
+							"admin-state": "enable",
+							"tls-profile": certData.ProfileName,
+						},
+					}},
+				},
+			},
+			{
+				Path: "/system/p4rt-server",
+				Value: map[string]any{
+					"admin-state": "enable",
+					"network-instance": []map[string]any{{
+						"name":        "mgmt",
+						"admin-state": "enable",
+						"tls-profile": certData.ProfileName,
+					}},
+				},
+			},
+		},
+		Tools: getSaveTools(),
+	}
+}
+
+// getSaveTools returns the tools update that saves the running config to the startup config.
+func getSaveTools() []bootstrap.Update {
+	return []bootstrap.Update{
+		{
+			Path:  "/tools/system/configuration/save",
+			Value: map[string]any{},
+		},
 	}
+}
 
+// cliTransport pushes the initial config over ssh using the cli
+type cliTransport struct {
+	address  string
+	username string
+	password string
+	certData *cert.CertData
+}
+
+// Push sends the cli commands of the initial config, the structured config is not used
+// since the cli requires the key and certificates to be sent separately.
+func (r *cliTransport) Push(ctx context.Context, _ *bootstrap.Config) error {
+	l := log.FromContext(ctx)
 	li, _ := logging.NewInstance(
 		logging.WithLevel(logging.Debug),
-		logging.WithLogger(log.Print),
+		logging.WithLogger(func(a ...interface{}) { l.V(1).Info(fmt.Sprint(a...)) }),
 	)
 
-	//fmt.Printf("certData: %v\n", *certData)
-	var channelLog bytes.Buffer
 	p, err := platform.NewPlatform(
 		scrapliGoSRLinuxKey,
-		ips[0].IP,
+		r.address,
 		options.WithAuthNoStrictKey(),
-		options.WithAuthUsername(string(secret.Data[defaultSecretUserNameKey])),
-		options.WithAuthPassword(string(secret.Data[defaultSecretPasswordKey])),
+		options.WithAuthUsername(r.username),
+		options.WithAuthPassword(r.password),
 		options.WithLogger(li),
-		options.WithChannelLog(&channelLog),
 		options.WithTermWidth(1000),
 	)
 	if err != nil {
@@ -310,6 +521,7 @@ func (r *srl) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []
 	}
 	defer d.Close()
 
+	certData := r.certData
 	commands := []string{
 		fmt.Sprintf("set / system tls server-profile %s", certData.ProfileName),
 		fmt.Sprintf("set / system tls server-profile %s authenticate-client false", certData.ProfileName),
@@ -368,77 +580,39 @@ func (r *srl) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []
 		}
 	*/
 
-	_, err = d.SendConfig("commit save")
-
-	prompt, err := d.GetPrompt()
+	resp, err := d.SendConfig("commit save")
 	if err != nil {
-		fmt.Printf("failed to get prompt; error: %+v\n", err)
 		return err
 	}
-
-	fmt.Printf("found prompt: %s\n\n\n", prompt)
-
-	// We can then read and print out the channel log data like normal
-	b := make([]byte, channelLog.Len())
-	_, _ = channelLog.Read(b)
-	fmt.Printf("Channel log output:\n%s", b)
-
+	if resp.Failed != nil {
+		return resp.Failed
+	}
+	l.Info("initial config committed", "address", r.address)
 	return nil
 }
 
-func (r *srl) getNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
-
-	if cr.Spec.NodeConfig != nil && cr.Spec.NodeConfig.Name != "" {
-		nc := &invv1alpha1.NodeConfig{}
-		if err := r.Get(ctx, types.NamespacedName{Name: cr.Spec.NodeConfig.Name, Namespace: os.Getenv("POD_NAMESPACE")}, nc); err != nil {
-			return nil, err
-		}
-		return nc, nil
-
-	}
-	// the nodeConfig was not provided, we list all nodeConfigs in the cr namespace
-	// we check if there is a nodeconfig with the name equal to the cr name + the provider matches
-	// if still not found we look at a nodeconfig with name default that matches the provider
-	// if still not found we return an empty nodeConfig, which populates the defaults
-
-	opts := []client.ListOption{
-		client.InNamespace(os.Getenv("POD_NAMESPACE")),
-	}
-	ncl := &invv1alpha1.NodeConfigList{}
-	if err := r.List(ctx, ncl, opts...); err != nil {
-		return nil, err
-	}
-
-	for _, nc := range ncl.Items {
-		// if there is a nodeconfig with the exact name of the node -> we return this nodeConfig
-		if nc.GetName() == cr.GetName() && cr.Spec.Provider == nc.Spec.Provider {
-			return &nc, nil
-		}
+func (r *srl) getVariant(ctx context.Context, cr *invv1alpha1.Node, model string) (string, error) {
+	variants, _, err := node.GetVariants(ctx, r.Client, cr.GetNamespace(), variantsCfgMapName)
+	if err != nil {
+		return "", err
 	}
-	for _, nc := range ncl.Items {
-		// if there is a nodeconfig with the name default -> we return this nodeConfig
-		if nc.GetName() == "default" && cr.Spec.Provider == nc.Spec.Provider {
-			return &nc, nil
-		}
-
+	variant, ok := variants.Data[model]
+	if !ok {
+		return "", fmt.Errorf("cannot deploy pod, variant not provided in the configmap, got: %s", model)
 	}
-	// if nothing is found we return an empty nodeconfig
-	return &invv1alpha1.NodeConfig{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: os.Getenv("POD_NAMESPACE"),
-		},
-	}, nil
+	return variant, nil
 }
 
-func (r *srl) checkVariants(ctx context.Context, cr *invv1alpha1.Node, model string) error {
-	variants := &corev1.ConfigMap{}
-	if err := r.Get(ctx, types.NamespacedName{Name: variantsCfgMapName, Namespace: cr.GetNamespace()}, variants); err != nil {
-		return err
+// getLicense returns the license of the node from the license secret.
+func (r *srl) getLicense(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]byte, error) {
+	if nc.Spec.LicenseKey == nil {
+		return nil, nil
 	}
-	if _, ok := variants.Data[model]; !ok {
-		return fmt.Errorf("cannot deploy pod, variant not provided in the configmap, got: %s", model)
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: licenseCfgMapName, Namespace: cr.GetNamespace()}, secret); err != nil {
+		return nil, fmt.Errorf("cannot get license %s, err: %w", licenseCfgMapName, err)
 	}
-	return nil
+	return secret.Data[*nc.Spec.LicenseKey], nil
 }
 
 func getContainers(name string, nodeConfig *invv1alpha1.NodeConfig) []corev1.Container {
@@ -493,7 +667,7 @@ func getAffinity(topology string) *corev1.Affinity {
 	}
 }
 
-func getVolumes(_ string, nodeConfig *invv1alpha1.NodeConfig) []corev1.Volume {
+func getVolumes(name string, nodeConfig *invv1alpha1.NodeConfig) []corev1.Volume {
 	vols := []corev1.Volume{
 		{
 			Name: variantsVolName,
@@ -532,18 +706,6 @@ func getVolumes(_ string, nodeConfig *invv1alpha1.NodeConfig) []corev1.Volume {
 				},
 			},
 		},
-		/*
-			{
-				Name: initialConfigVolName,
-				VolumeSource: corev1.VolumeSource{
-					ConfigMap: &corev1.ConfigMapVolumeSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: initialConfigCfgMapName,
-						},
-					},
-				},
-			},
-		*/
 		/*
 			{
 				Name: strings.Join([]string{certificateProfileName, certificateVolName}, "-"),
@@ -559,6 +721,9 @@ func getVolumes(_ string, nodeConfig *invv1alpha1.NodeConfig) []corev1.Volume {
 	if nodeConfig.Spec.LicenseKey != nil {
 		vols = append(vols, getLicenseVolume(nodeConfig))
 	}
+	if nodeConfig.Spec.StartupConfig != nil {
+		vols = append(vols, getStartupConfigVolume(name))
+	}
 	return vols
 }
 
@@ -577,13 +742,6 @@ func getVolumeMounts(nodeConfig *invv1alpha1.NodeConfig) []corev1.VolumeMount {
 			MountPath: k8sEntrypointVolMntPath,
 			SubPath:   k8sEntrypointVolMntSubPath,
 		},
-		/*
-			{
-				Name:      initialConfigVolName,
-				MountPath: initialConfigVolMntPath,
-				ReadOnly:  false,
-			},
-		*/
 		/*
 			{
 				Name:      strings.Join([]string{certificateProfileName, certificateVolName}, "-"),
@@ -596,6 +754,9 @@ func getVolumeMounts(nodeConfig *invv1alpha1.NodeConfig) []corev1.VolumeMount {
 	if nodeConfig.Spec.LicenseKey != nil {
 		vms = append(vms, getLicenseVolumeMount())
 	}
+	if nodeConfig.Spec.StartupConfig != nil {
+		vms = append(vms, getStartupConfigVolumeMount())
+	}
 
 	return vms
 }
@@ -625,6 +786,34 @@ func getLicenseVolume(nodeConfig *invv1alpha1.NodeConfig) corev1.Volume {
 	}
 }
 
+func getStartupConfigVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      startupConfigVolName,
+		MountPath: startupConfigVolMntPath,
+		ReadOnly:  true,
+	}
+}
+
+func getStartupConfigVolume(nodeName string) corev1.Volume {
+	return corev1.Volume{
+		Name: startupConfigVolName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: node.GetStartupConfigMapName(nodeName),
+				},
+			},
+		},
+	}
+}
+
+func getStartupConfigData(cm *corev1.ConfigMap) map[string]string {
+	if cm == nil {
+		return nil
+	}
+	return cm.Data
+}
+
 /*
 func GetSelectorLabels(name string) map[string]string {
 	return map[string]string{
@@ -0,0 +1,119 @@
+package srlinux
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testPodNamespace = "network-system"
+
+func getTestNodeModel(model string, annotations map[string]string, itfces ...string) *invv1alpha1.NodeModel {
+	nm := &invv1alpha1.NodeModel{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   testPodNamespace,
+			Name:        NokiaSRLinuxProvider + "-" + model,
+			Annotations: annotations,
+		},
+	}
+	for _, itfce := range itfces {
+		nm.Spec.Interfaces = append(nm.Spec.Interfaces, invv1alpha1.NodeModelInterface{Name: itfce})
+	}
+	return nm
+}
+
+func TestGetNetworkAttachmentDefinitions(t *testing.T) {
+	cases := map[string]struct {
+		nms []client.Object
+		// want is the mtu of the nads keyed by the name of the nad
+		want    map[string]int
+		wantErr bool
+	}{
+		"MultipleInterfaces": {
+			nms: []client.Object{getTestNodeModel("ixrd3", nil, "e1-1", "e1-2", "e1-3")},
+			want: map[string]int{
+				"node1-e1-1": defaultInterfaceMTU,
+				"node1-e1-2": defaultInterfaceMTU,
+				"node1-e1-3": defaultInterfaceMTU,
+			},
+		},
+		"InterfaceMTU": {
+			nms: []client.Object{getTestNodeModel("ixrd3", map[string]string{
+				node.InterfaceMTUsKey: `{"e1-1": 9000, "e1-3": 1500}`,
+			}, "e1-1", "e1-2", "e1-3")},
+			want: map[string]int{
+				"node1-e1-1": 9000,
+				"node1-e1-2": defaultInterfaceMTU,
+				"node1-e1-3": 1500,
+			},
+		},
+		"InvalidMTU": {
+			nms: []client.Object{getTestNodeModel("ixrd3", map[string]string{
+				node.InterfaceMTUsKey: `{"e1-1": 0}`,
+			}, "e1-1")},
+			wantErr: true,
+		},
+		"NoNodeModel": {
+			nms:     []client.Object{getTestNodeModel("ixrd2", nil, "e1-1")},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("POD_NAMESPACE", testPodNamespace)
+			s := runtime.NewScheme()
+			if err := invv1alpha1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			if err := nadv1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			r := &srl{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(tc.nms...).Build(), scheme: s}
+			cr := &invv1alpha1.Node{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1"},
+				Spec:       invv1alpha1.NodeSpec{Provider: NokiaSRLinuxProvider},
+			}
+			nc := &invv1alpha1.NodeConfig{Spec: invv1alpha1.NodeConfigSpec{Model: pointer.String("ixrd3")}}
+
+			nads, err := r.GetNetworkAttachmentDefinitions(context.Background(), cr, nc)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("want error, got: %d nads", len(nads))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := map[string]int{}
+			for _, n := range nads {
+				cfg := struct {
+					Plugins []struct {
+						MTU int `json:"mtu"`
+					} `json:"plugins"`
+				}{}
+				if err := json.Unmarshal([]byte(n.Spec.Config), &cfg); err != nil {
+					t.Fatal(err)
+				}
+				if len(cfg.Plugins) == 0 {
+					t.Fatalf("nad %s has no plugins", n.GetName())
+				}
+				got[n.GetName()] = cfg.Plugins[0].MTU
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
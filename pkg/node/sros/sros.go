@@ -1,18 +1,22 @@
-package srlinux
+package sros
 
 import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
+	"sort"
+	"time"
 
+	"github.com/henderiw-nephio/network-node-operator/pkg/bootstrap"
 	"github.com/henderiw-nephio/network-node-operator/pkg/cert"
 	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
 	"github.com/henderiw-nephio/network-node-operator/pkg/node"
 	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
-	"github.com/scrapli/scrapligo/driver/opoptions"
+	"github.com/scrapli/scrapligo/driver/network"
 	"github.com/scrapli/scrapligo/driver/options"
 	"github.com/scrapli/scrapligo/platform"
 	corev1 "k8s.io/api/core/v1"
@@ -22,13 +26,18 @@ import (
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 )
 
 const (
 	NokiaSROSProvider    = "sros.nokia.com"
-	defaultSROSImageName = "ghcr.io/nokia/srlinux:latest"
-	defaultSROSVariant   = "ixrd3l"
+	defaultSROSImageName = "registry.srlinux.dev/pub/nokia_srsim:latest"
+	defaultSROSVariant   = "sr-1"
 	scrapliGoSROSKey     = "nokia_sros"
+	defaultInterfaceMTU  = 9500
+	gnmiPort             = "57400"
+	softwareVersionPath  = "/state/system/version/version-number"
+	platformPath         = "/state/system/platform"
 
 	//
 	startupInitialDelay      = 15
@@ -44,24 +53,27 @@ const (
 	podAffinityWeight        = 100
 
 	// volumes
-	//initialConfigVolMntPath  = "/tmp/initial-config"
-	//initialConfigCfgMapName  = "sros-initial-config"
 	defaultSecretUserNameKey = "username"
 	defaultSecretPasswordKey = "password"
+	variantsCfgMapName       = "sros.nokia.com-variants"
+	licenseCfgMapName        = "licenses.sros.nokia.com"
+	licensesVolName          = "license"
+	licenseFileName          = "license.txt"
+	licenseMntPath           = "/nokia/license/"
+	hugePagesVolName         = "hugepages"
+	hugePagesMntPath         = "/dev/hugepages"
+	flashVolName             = "flash3"
+	flashMntPath             = "/home/sros/flash3"
+	startupConfigVolName     = "startup-config"
+	startupConfigVolMntPath  = "/tmp/startup-config"
+	startupConfigInitName    = "startup-config"
 	certificateProfileName   = "k8s-profile"
-	//certificateVolName         = "serving-cert"
-	//certificateVolMntPath      = "serving-certs"
-	//initialConfigVolName       = "initial-config-volume"
-	licenseCfgMapName = "licenses.sros.nokia.com"
-	licensesVolName   = "license"
-	licenseFileName   = "license.txt"
-	licenseMntPath    = "/nokia/license/"
-	hugePagesVolName  = "hugepages"
-	hugePagesMntPath  = "/dev/hugepages"
-	banner            = `................................................................
-	:                  Welcome to Nokia SROS!                      :
-	................................................................
-	`
+	certificateVolName       = "certificate"
+	certificateMntPath       = flashMntPath + "/" + certificateVolName
+	certificateURL           = "cf3:/" + certificateVolName
+	certificateFileName      = "k8s-profile.crt"
+	keyFileName              = "k8s-profile.key"
+	banner                   = "Welcome to Nokia SR OS!"
 )
 
 var (
@@ -73,14 +85,6 @@ var (
 	//nolint:gochecknoglobals
 	defaultArgs = []string{}
 
-	//nolint:gochecknoglobals
-	defaultEnv = []corev1.EnvVar{
-		{
-			Name:  "SRLINUX",
-			Value: "1",
-		},
-	}
-
 	//nolint:gochecknoglobals
 	defaultResourceRequests = map[string]string{
 		"cpu":    "2",
@@ -112,13 +116,39 @@ func (r *sros) GetProviderType(ctx context.Context) node.ProviderType {
 	return node.ProviderTypeNetwork
 }
 
+func (r *sros) GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec {
+	return &invv1alpha1.NodeConfigSpec{
+		Provider: NokiaSROSProvider,
+		Model:    pointer.String(defaultSROSVariant),
+		Image:    pointer.String(defaultSROSImageName),
+	}
+}
+
+// ValidateNodeConfig validates the model against the variants and the license key
+// against the licenses in the namespace of the node.
+func (r *sros) ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error {
+	if nc.Spec.Model != nil {
+		if err := node.ValidateVariant(ctx, r.Client, cr.GetNamespace(), variantsCfgMapName, *nc.Spec.Model); err != nil {
+			return err
+		}
+	}
+	if nc.Spec.LicenseKey != nil {
+		return node.ValidateLicenseKey(ctx, r.Client, types.NamespacedName{Namespace: cr.GetNamespace(), Name: licenseCfgMapName}, *nc.Spec.LicenseKey)
+	}
+	return nil
+}
+
 func (r *sros) GetNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
-	// get nodeConfig via paramRef
-	nodeConfig, err := r.getNodeConfig(ctx, cr)
+	// get nodeConfig by merging the provider defaults with the node config layers
+	nodeConfig, err := node.GetNodeConfig(ctx, r.Client, cr, r.GetNodeConfigDefaults(ctx))
 	if err != nil {
 		return nil, err
 	}
 
+	// validate if the model returned exists in the variant list
+	if _, err := r.getVariant(ctx, cr, nodeConfig.GetModel(defaultSROSVariant)); err != nil {
+		return nil, err
+	}
 	return nodeConfig, nil
 }
 
@@ -142,74 +172,36 @@ func (r *sros) GetNodeModel(ctx context.Context, nc *invv1alpha1.NodeConfig) (*i
 	return nm, nil
 }
 
-func (r *sros) GetInterfaces(ctx context.Context, nc *invv1alpha1.NodeConfig) (*invv1alpha1.NodeModel, error) {
-	nm := &invv1alpha1.NodeModel{}
-	if err := r.Get(ctx, types.NamespacedName{
-		Name:      fmt.Sprintf("%s-%s", NokiaSROSProvider, nc.GetModel(defaultSROSVariant)),
-		Namespace: os.Getenv("POD_NAMESPACE"),
-	}, nm); err != nil {
-		return nil, err
+func (r *sros) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultSROSVariant), err)
 	}
-	return nm, nil
+	itfces := make([]node.Interface, 0, len(nm.Spec.Interfaces))
+	for _, itfce := range nm.Spec.Interfaces {
+		itfces = append(itfces, node.Interface{
+			Name: itfce.Name,
+			MTU:  defaultInterfaceMTU,
+		})
+	}
+	return node.GetNetworkAttachmentDefinitions(cr, nc, itfces, r.scheme)
 }
 
-func (r *sros) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
-	// todo check node model and get interfaces from the model
-	nads := []*nadv1.NetworkAttachmentDefinition{}
-	/*
-		for _, ifName := range ifNames {
-			b, err := nad.GetNadConfig([]nad.PluginConfigInterface{
-				nad.WirePlugin{
-					PluginCniType: nad.PluginCniType{
-						Type: nad.WirePluginType,
-					},
-					InterfaceName: ifName,
-				},
-			})
-			if err != nil {
-				return nil, err
-			}
-
-			n := &nadv1.NetworkAttachmentDefinition{
-				TypeMeta: metav1.TypeMeta{
-					APIVersion: nadv1.SchemeGroupVersion.Identifier(),
-					Kind:       reflect.TypeOf(nadv1.NetworkAttachmentDefinition{}).Name(),
-				},
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: cr.GetNamespace(),
-					Name:      strings.Join([]string{cr.GetName(), ifName}, "-"),
-				},
-				Spec: nadv1.NetworkAttachmentDefinitionSpec{
-					Config: string(b),
-				},
-			}
-			if err := ctrl.SetControllerReference(cr, n, r.scheme); err != nil {
-				return nil, err
-			}
-			nads = append(nads, n)
-		}
-	*/
-	return nads, nil
+func (r *sros) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
+	return node.GetPersistentVolumeClaims(cr, nc, r.scheme)
 }
 
-func (r *sros) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
-	pvcs := []*corev1.PersistentVolumeClaim{}
-	for _, pv := range nc.Spec.PersistentVolumes {
-		pvc := &corev1.PersistentVolumeClaim{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      fmt.Sprintf("%s-%s", cr.GetName(), pv.Name),
-				Namespace: cr.GetNamespace(),
-			},
-			Spec: corev1.PersistentVolumeClaimSpec{
-				AccessModes: []corev1.PersistentVolumeAccessMode{},
-				Resources: corev1.ResourceRequirements{
-					Requests: pv.Requests,
-				},
-			},
-		}
-		pvcs = append(pvcs, pvc)
+// GetStartupConfig returns the config map with the startup config of the node rendered
+// from the startup config templates referenced in the node config.
+func (r *sros) GetStartupConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) (*corev1.ConfigMap, error) {
+	if nc.Spec.StartupConfig == nil {
+		return nil, nil
 	}
-	return pvcs, nil
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultSROSVariant), err)
+	}
+	return node.GetStartupConfig(ctx, r.Client, cr, nc, nm, r.scheme)
 }
 
 func (r *sros) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*corev1.Pod, error) {
@@ -218,20 +210,39 @@ func (r *sros) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1al
 		return nil, err
 	}
 
+	variant, err := r.getVariant(ctx, cr, nc.GetModel(defaultSROSVariant))
+	if err != nil {
+		return nil, err
+	}
+
+	startupConfig, err := r.GetStartupConfig(ctx, cr, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	license, err := r.getLicense(ctx, cr, nc)
+	if err != nil {
+		return nil, err
+	}
+
 	d := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.GetName(),
 			Namespace: cr.GetNamespace(),
 		},
 		Spec: corev1.PodSpec{
-			Containers:   getContainers(cr.GetName(), nc),
-			NodeSelector: map[string]string{},
-			Affinity:     getAffinity(cr.GetNamespace()),
-			Volumes:      getVolumes(cr.GetName(), nc),
+			InitContainers: getInitContainers(nc),
+			Containers:     getContainers(cr.GetName(), nc, variant),
+			NodeSelector:   map[string]string{},
+			Affinity:       getAffinity(cr.GetNamespace()),
+			Volumes:        getVolumes(cr.GetName(), nc),
 		},
 	}
 
-	hashString := getHash(d.Spec)
+	// the nad annotation is part of the hash since the networks of a pod
+	// are only attached when the pod is created, the startup config data and
+	// the license are part of the hash since they are only read when the pod starts
+	hashString := getHash([]any{d.Spec, string(nadAnnotation), getStartupConfigData(startupConfig), license})
 	if len(d.GetAnnotations()) == 0 {
 		d.ObjectMeta.Annotations = map[string]string{}
 	}
@@ -241,143 +252,230 @@ func (r *sros) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1al
 		d.ObjectMeta.Annotations[nadv1.NetworkAttachmentAnnot] = string(nadAnnotation)
 	}
 
+	if len(d.GetLabels()) == 0 {
+		d.ObjectMeta.Labels = map[string]string{}
+	}
+	d.ObjectMeta.Labels[invv1alpha1.NephioTopologyKey] = cr.Namespace
+
 	if err := ctrl.SetControllerReference(cr, d, r.scheme); err != nil {
 		return nil, err
 	}
 	return d, nil
 }
 
+// SetInitialConfig imports the certificate of the node that is mounted on the flash
+// and enables the gnmi server with a tls server profile of the certificate using the
+// MD-CLI, since SR OS expects the certificates as files on the compact flash.
 func (r *sros) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
-	secret := &corev1.Secret{}
-	// we assume right now the default secret name is equal to the provider
-	// this provider username and password
-	if err := r.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: NokiaSROSProvider}, secret); err != nil {
+	username, password, err := r.getCredentials(ctx, cr)
+	if err != nil {
 		return err
 	}
 
-	certSecret := &corev1.Secret{}
-	// this is used to provide certificate for the gnmi/gnsi/etc servers on the device
-	if err := r.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}, certSecret); err != nil {
+	d, err := openDriver(ips[0].IP, username, password)
+	if err != nil {
 		return err
 	}
+	defer d.Close()
+
+	for _, cmd := range getCertificateCommands() {
+		resp, err := d.SendCommand(cmd)
+		if err != nil {
+			return err
+		}
+		if resp.Failed != nil {
+			return resp.Failed
+		}
+	}
 
-	certData, err := cert.GetCertificateData(certSecret, certificateProfileName)
+	mresp, err := d.SendConfigs(getInitialConfig(username))
 	if err != nil {
 		return err
 	}
+	if mresp.Failed != nil {
+		return mresp.Failed
+	}
 
-	//fmt.Printf("certData: %v\n", *certData)
-
-	p, err := platform.NewPlatform(
-		scrapliGoSROSKey,
-		ips[0].IP,
-		options.WithAuthNoStrictKey(),
-		options.WithAuthUsername(string(secret.Data[defaultSecretUserNameKey])),
-		options.WithAuthPassword(string(secret.Data[defaultSecretPasswordKey])),
-	)
+	resp, err := d.SendCommand("admin save")
 	if err != nil {
 		return err
 	}
-	d, err := p.GetNetworkDriver()
+	return resp.Failed
+}
+
+// GetInitialConfigHash returns the hash of the initial config commands, which includes
+// the certificate data, such that a rotation of the certificate changes the hash.
+func (r *sros) GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error) {
+	username, _, err := r.getCredentials(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+	certData, err := r.getCertData(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+	return getHash([]any{certData, getCertificateCommands(), getInitialConfig(username)}), nil
+}
+
+// GetDeviceInfo reads the software version and the platform from the node using gnmi,
+// which is enabled with tls by the initial config.
+func (r *sros) GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error) {
+	username, password, err := r.getCredentials(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	values, err := bootstrap.GetValues(ctx, bootstrap.GNMIConfig{
+		Address:    net.JoinHostPort(ips[0].IP, gnmiPort),
+		Username:   username,
+		Password:   password,
+		SkipVerify: true,
+	}, softwareVersionPath, platformPath)
+	if err != nil {
+		return nil, err
+	}
+	return &node.DeviceInfo{
+		SoftwareVersion: values[softwareVersionPath],
+		Chassis:         values[platformPath],
+	}, nil
+}
+
+// Teardown saves the running config of the node before the node is deleted, such that
+// the config is preserved on the persistent volumes of the node. The license is mounted
+// from a shared config map, so there is no license allocation to release.
+func (r *sros) Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	username, password, err := r.getCredentials(ctx, cr)
 	if err != nil {
 		return err
 	}
-	err = d.Open()
+	d, err := openDriver(ips[0].IP, username, password)
 	if err != nil {
 		return err
 	}
 	defer d.Close()
 
-	commands := []string{
-		"enter candidate private\n",
-		fmt.Sprintf("set / system tls server-profile %s\n", certData.ProfileName),
-		fmt.Sprintf("set / system tls server-profile %s authenticate-client false\n", certData.ProfileName),
-		fmt.Sprintf("set / system tls server-profile %s key \"%s\" \n", certData.ProfileName, certData.Key),
-		fmt.Sprintf("set / system tls server-profile %s certificate \"%s\" \n", certData.ProfileName, certData.Cert),
-		fmt.Sprintf("set / system tls server-profile %s trust-anchor \"%s\" \n", certData.ProfileName, certData.CA),
-		"set / system lldp admin state enable\n",
-		"set / system gnmi-server admin-state enable\n",
-		"set / system gnmi-server rate-limit 65000\n",
-		"set / system gnmi-server trace-options [ common request response ]\n",
-		"set / system gnmi-server network-instance mgmt admin-state enable\n",
-		fmt.Sprintf("set / system gnmi-server network-instance mgmt tls-profile %s \n", certData.ProfileName),
-		"set / system gnmi-server network-instance mgmt unix-socket admin-state enable\n",
-		"set / system gribi-server admin-state enable\n",
-		"set / system gribi-server network-instance mgmt admin-state enable\n",
-		fmt.Sprintf("set / system gribi-server network-instance mgmt tls-profile %s \n", certData.ProfileName),
-		"set / system json-rpc-server admin-state enable\n",
-		"set / system json-rpc-server network-instance mgmt http admin-state enable\n",
-		"set / system json-rpc-server network-instance mgmt https admin-state enable\n",
-		fmt.Sprintf("set / system json-rpc-server network-instance mgmt https tls-profile %s \n", certData.ProfileName),
-		"set / system p4rt-server admin-state enable\n",
-		"set / system p4rt-server network-instance mgmt admin-state enable\n",
-		fmt.Sprintf("set / system p4rt-server network-instance mgmt tls-profile %s \n", certData.ProfileName),
-		fmt.Sprintf("set / system banner login-banner \"%s\" \n", banner),
-		"commit save",
-	}
-
-	//fmt.Printf("commands:\n%v\n", commands)
-
-	_, err = d.SendCommands(commands, opoptions.WithEager())
+	resp, err := d.SendCommand("admin save")
 	if err != nil {
 		return err
 	}
+	return resp.Failed
+}
 
-	return nil
-
+// openDriver opens a MD-CLI session to the node.
+func openDriver(address, username, password string) (*network.Driver, error) {
+	p, err := platform.NewPlatform(
+		scrapliGoSROSKey,
+		address,
+		options.WithAuthNoStrictKey(),
+		options.WithAuthUsername(username),
+		options.WithAuthPassword(password),
+	)
+	if err != nil {
+		return nil, err
+	}
+	d, err := p.GetNetworkDriver()
+	if err != nil {
+		return nil, err
+	}
+	d.Channel.TimeoutOps = 5 * time.Second
+	if err := d.Open(); err != nil {
+		return nil, err
+	}
+	return d, nil
 }
 
-func (r *sros) getNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
+func (r *sros) getCredentials(ctx context.Context, cr *invv1alpha1.Node) (string, string, error) {
+	secret := &corev1.Secret{}
+	// we assume right now the default secret name is equal to the provider
+	// this provider username and password
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: NokiaSROSProvider}, secret); err != nil {
+		return "", "", err
+	}
+	return string(secret.Data[defaultSecretUserNameKey]), string(secret.Data[defaultSecretPasswordKey]), nil
+}
 
-	if cr.Spec.NodeConfig != nil && cr.Spec.NodeConfig.Name != "" {
-		nc := &invv1alpha1.NodeConfig{}
-		if err := r.Get(ctx, types.NamespacedName{Name: cr.Spec.NodeConfig.Name, Namespace: os.Getenv("POD_NAMESPACE")}, nc); err != nil {
-			return nil, err
-		}
-		return nc, nil
+func (r *sros) getCertData(ctx context.Context, cr *invv1alpha1.Node) (*cert.CertData, error) {
+	certSecret := &corev1.Secret{}
+	// this is used to provide certificate for the gnmi server on the device
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}, certSecret); err != nil {
+		return nil, err
+	}
+	return cert.GetCertificateData(certSecret, certificateProfileName)
+}
 
+// getCertificateCommands returns the commands that import the certificate and the key
+// of the certificate secret, which is mounted on the compact flash, in the pki of the node.
+func getCertificateCommands() []string {
+	return []string{
+		fmt.Sprintf("admin system security pki import type certificate input-url %s/tls.crt output-file %s format pem",
+			certificateURL, certificateFileName),
+		fmt.Sprintf("admin system security pki import type key input-url %s/tls.key output-file %s format pem",
+			certificateURL, keyFileName),
 	}
-	// the nodeConfig was not provided, we list all nodeConfigs in the cr namespace
-	// we check if there is a nodeconfig with the name equal to the cr name + the provider matches
-	// if still not found we look at a nodeconfig with name default that matches the provider
-	// if still not found we return an empty nodeConfig, which populates the defaults
+}
 
-	opts := []client.ListOption{
-		client.InNamespace(os.Getenv("POD_NAMESPACE")),
+// getInitialConfig returns the initial config commands of the node.
+// The driver enters the exclusive MD-CLI configuration mode to send the configs,
+// gnmi is enabled with a tls server profile of the imported certificate.
+func getInitialConfig(username string) []string {
+	return []string{
+		"/configure system lldp admin-state enable",
+		fmt.Sprintf("/configure system login-control motd text %q", banner),
+		fmt.Sprintf("/configure system security tls cert-profile %q admin-state enable", certificateProfileName),
+		fmt.Sprintf("/configure system security tls cert-profile %q entry 1 certificate-file %q", certificateProfileName, certificateFileName),
+		fmt.Sprintf("/configure system security tls cert-profile %q entry 1 key-file %q", certificateProfileName, keyFileName),
+		fmt.Sprintf("/configure system security tls server-cipher-list %q tls12-cipher 1 name tls-ecdhe-rsa-aes128-gcm-sha256", certificateProfileName),
+		fmt.Sprintf("/configure system security tls server-cipher-list %q tls12-cipher 2 name tls-ecdhe-rsa-aes256-gcm-sha384", certificateProfileName),
+		fmt.Sprintf("/configure system security tls server-tls-profile %q admin-state enable", certificateProfileName),
+		fmt.Sprintf("/configure system security tls server-tls-profile %q cert-profile %q", certificateProfileName, certificateProfileName),
+		fmt.Sprintf("/configure system security tls server-tls-profile %q cipher-list %q", certificateProfileName, certificateProfileName),
+		"/configure system grpc admin-state enable",
+		fmt.Sprintf("/configure system grpc tls-server-profile %q", certificateProfileName),
+		"/configure system grpc gnmi admin-state enable",
+		"/configure system grpc gnmi auto-config-save true",
+		"/configure system grpc rib-api admin-state enable",
+		fmt.Sprintf("/configure system security user-params local-user user %q access grpc true", username),
+		"commit",
 	}
-	ncl := &invv1alpha1.NodeConfigList{}
-	if err := r.List(ctx, ncl, opts...); err != nil {
+}
+
+// getVariant returns the variant of the model from the variants configmap.
+// The variant is a yaml map of the environment variables that define the
+// chassis, cards and mdas of the SR OS model.
+func (r *sros) getVariant(ctx context.Context, cr *invv1alpha1.Node, model string) (map[string]string, error) {
+	variants, _, err := node.GetVariants(ctx, r.Client, cr.GetNamespace(), variantsCfgMapName)
+	if err != nil {
 		return nil, err
 	}
-
-	for _, nc := range ncl.Items {
-		// if there is a nodeconfig with the exact name of the node -> we return this nodeConfig
-		if nc.GetName() == cr.GetName() && cr.Spec.Provider == nc.Spec.Provider {
-			return &nc, nil
-		}
+	data, ok := variants.Data[model]
+	if !ok {
+		return nil, fmt.Errorf("cannot deploy pod, variant not provided in the configmap, got: %s", model)
 	}
-	for _, nc := range ncl.Items {
-		// if there is a nodeconfig with the name default -> we return this nodeConfig
-		if nc.GetName() == "default" && cr.Spec.Provider == nc.Spec.Provider {
-			return &nc, nil
-		}
+	variant := map[string]string{}
+	if err := yaml.Unmarshal([]byte(data), &variant); err != nil {
+		return nil, fmt.Errorf("cannot deploy pod, invalid variant %s in the configmap, err: %w", model, err)
+	}
+	return variant, nil
+}
 
+// getLicense returns the license of the node from the license secret.
+func (r *sros) getLicense(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]byte, error) {
+	if nc.Spec.LicenseKey == nil {
+		return nil, nil
 	}
-	// if nothing is found we return an empty nodeconfig
-	return &invv1alpha1.NodeConfig{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: os.Getenv("POD_NAMESPACE"),
-		},
-	}, nil
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: licenseCfgMapName, Namespace: cr.GetNamespace()}, secret); err != nil {
+		return nil, fmt.Errorf("cannot get license %s, err: %w", licenseCfgMapName, err)
+	}
+	return secret.Data[*nc.Spec.LicenseKey], nil
 }
 
-func getContainers(name string, nc *invv1alpha1.NodeConfig) []corev1.Container {
+func getContainers(name string, nc *invv1alpha1.NodeConfig, variant map[string]string) []corev1.Container {
 	return []corev1.Container{{
 		Name:            name,
 		Image:           nc.GetImage(defaultSROSImageName),
 		Command:         defaultCmd,
 		Args:            defaultArgs,
-		Env:             defaultEnv,
+		Env:             getEnv(variant),
 		Resources:       nc.GetResourceRequirements(defaultResourceRequests, defaultResourceLimits),
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		SecurityContext: &corev1.SecurityContext{
@@ -418,6 +516,19 @@ func getContainers(name string, nc *invv1alpha1.NodeConfig) []corev1.Container {
 	}}
 }
 
+// getEnv returns the environment variables of the variant sorted by name
+// such that the hash of the pod spec is stable.
+func getEnv(variant map[string]string) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0, len(variant))
+	for k, v := range variant {
+		env = append(env, corev1.EnvVar{Name: k, Value: v})
+	}
+	sort.Slice(env, func(i, j int) bool {
+		return env[i].Name < env[j].Name
+	})
+	return env
+}
+
 func getAffinity(topology string) *corev1.Affinity {
 	return &corev1.Affinity{
 		PodAntiAffinity: &corev1.PodAntiAffinity{
@@ -427,7 +538,7 @@ func getAffinity(topology string) *corev1.Affinity {
 					PodAffinityTerm: corev1.PodAffinityTerm{
 						LabelSelector: &metav1.LabelSelector{
 							MatchExpressions: []metav1.LabelSelectorRequirement{{
-								Key:      "topo",
+								Key:      invv1alpha1.NephioTopologyKey,
 								Operator: "In",
 								Values:   []string{topology},
 							}},
@@ -440,23 +551,34 @@ func getAffinity(topology string) *corev1.Affinity {
 	}
 }
 
-func getVolumes(_ string, nc *invv1alpha1.NodeConfig) []corev1.Volume {
+func getVolumes(name string, nc *invv1alpha1.NodeConfig) []corev1.Volume {
 	vols := []corev1.Volume{}
 	vols = append(vols, getHugePagesVolume())
+	vols = append(vols, getCertificateVolume(name))
 
 	for _, pv := range nc.Spec.PersistentVolumes {
-		vols = append(vols, getPersistentVolume(pv.Name))
+		vols = append(vols, getPersistentVolume(name, pv.Name))
 	}
 
 	if nc.Spec.LicenseKey != nil {
 		vols = append(vols, getLicenseVolume(nc))
 	}
+	if nc.Spec.StartupConfig != nil {
+		vols = append(vols, getStartupConfigVolume(name))
+		if !hasPersistentFlash(nc) {
+			vols = append(vols, corev1.Volume{
+				Name:         flashVolName,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			})
+		}
+	}
 	return vols
 }
 
 func getVolumeMounts(nc *invv1alpha1.NodeConfig) []corev1.VolumeMount {
 	vms := []corev1.VolumeMount{}
 	vms = append(vms, getHugePagesVolumeMount())
+	vms = append(vms, getCertificateVolumeMount())
 
 	for _, pv := range nc.Spec.PersistentVolumes {
 		vms = append(vms, getPersistentVolumeMount(pv.Name, pv.MountPath))
@@ -465,10 +587,57 @@ func getVolumeMounts(nc *invv1alpha1.NodeConfig) []corev1.VolumeMount {
 	if nc.Spec.LicenseKey != nil {
 		vms = append(vms, getLicenseVolumeMount())
 	}
+	if nc.Spec.StartupConfig != nil && !hasPersistentFlash(nc) {
+		vms = append(vms, getPersistentVolumeMount(flashVolName, flashMntPath))
+	}
 
 	return vms
 }
 
+// getInitContainers returns an init container that copies the startup config
+// to the flash of the node, since SR OS saves the config to the same file
+// the startup config cannot be mounted read only from the config map.
+func getInitContainers(nc *invv1alpha1.NodeConfig) []corev1.Container {
+	if nc.Spec.StartupConfig == nil {
+		return nil
+	}
+	flashVolMnt := getPersistentVolumeMount(flashVolName, flashMntPath)
+	for _, pv := range nc.Spec.PersistentVolumes {
+		if pv.MountPath == flashMntPath {
+			flashVolMnt = getPersistentVolumeMount(pv.Name, pv.MountPath)
+		}
+	}
+	return []corev1.Container{{
+		Name:            startupConfigInitName,
+		Image:           nc.GetImage(defaultSROSImageName),
+		Command:         []string{"sh", "-c"},
+		Args:            []string{fmt.Sprintf("cp -L %s/* %s/", startupConfigVolMntPath, flashMntPath)},
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser: pointer.Int64(0),
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      startupConfigVolName,
+				MountPath: startupConfigVolMntPath,
+				ReadOnly:  true,
+			},
+			flashVolMnt,
+		},
+	}}
+}
+
+// hasPersistentFlash returns true when a persistent volume is mounted on the flash
+// of the node.
+func hasPersistentFlash(nc *invv1alpha1.NodeConfig) bool {
+	for _, pv := range nc.Spec.PersistentVolumes {
+		if pv.MountPath == flashMntPath {
+			return true
+		}
+	}
+	return false
+}
+
 func getLicenseVolumeMount() corev1.VolumeMount {
 	return corev1.VolumeMount{
 		Name:      licensesVolName,
@@ -493,6 +662,27 @@ func getLicenseVolume(nodeConfig *invv1alpha1.NodeConfig) corev1.Volume {
 	}
 }
 
+func getCertificateVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      certificateVolName,
+		MountPath: certificateMntPath,
+		ReadOnly:  true,
+	}
+}
+
+// getCertificateVolume returns the volume of the certificate secret of the node, which
+// has the name of the node.
+func getCertificateVolume(nodeName string) corev1.Volume {
+	return corev1.Volume{
+		Name: certificateVolName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: nodeName,
+			},
+		},
+	}
+}
+
 func getHugePagesVolumeMount() corev1.VolumeMount {
 	return corev1.VolumeMount{
 		Name:      hugePagesVolName,
@@ -518,17 +708,37 @@ func getPersistentVolumeMount(name, mounthPath string) corev1.VolumeMount {
 	}
 }
 
-func getPersistentVolume(name string) corev1.Volume {
+func getPersistentVolume(nodeName, name string) corev1.Volume {
 	return corev1.Volume{
 		Name: name,
 		VolumeSource: corev1.VolumeSource{
 			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: fmt.Sprintf("pvc-%s", name),
+				ClaimName: node.GetPersistentVolumeClaimName(nodeName, name),
 			},
 		},
 	}
 }
 
+func getStartupConfigVolume(nodeName string) corev1.Volume {
+	return corev1.Volume{
+		Name: startupConfigVolName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: node.GetStartupConfigMapName(nodeName),
+				},
+			},
+		},
+	}
+}
+
+func getStartupConfigData(cm *corev1.ConfigMap) map[string]string {
+	if cm == nil {
+		return nil
+	}
+	return cm.Data
+}
+
 func getHash(x any) string {
 	b, err := json.Marshal(x)
 	if err != nil {
@@ -0,0 +1,165 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IPClaimGroupVersionKind is the gvk of the ip claims of the ipam, the ip claims
+// are read unstructured to avoid a dependency on the ipam api.
+var IPClaimGroupVersionKind = schema.GroupVersionKind{
+	Group:   "ipam.resource.nephio.org",
+	Version: "v1alpha1",
+	Kind:    "IPClaim",
+}
+
+// StartupConfigInput is the data that is available in the startup config templates.
+type StartupConfigInput struct {
+	// Name of the node
+	Name string
+	// Namespace of the node
+	Namespace string
+	// Labels of the node
+	Labels map[string]string
+	// Provider of the node
+	Provider string
+	// Model of the node
+	Model string
+	// Interfaces of the node model
+	Interfaces []invv1alpha1.NodeModelInterface
+	// IPs contains the prefixes allocated to the ip claims of the node.
+	// The ip claims are selected by the node name label and keyed by the
+	// name of the claim without the node name prefix, e.g. leaf1-system -> system
+	IPs map[string]string
+}
+
+// GetStartupConfigMapName returns the name of the config map that contains
+// the rendered startup config of the node.
+func GetStartupConfigMapName(nodeName string) string {
+	return strings.Join([]string{nodeName, "startup-config"}, "-")
+}
+
+// GetStartupConfig renders the startup config templates referenced in the node config
+// and returns a config map with the rendered startup config for the node.
+// Every key of the referenced config map is a go text/template that is rendered
+// with the StartupConfigInput of the node. When no startup config is referenced
+// no config map is returned.
+func GetStartupConfig(ctx context.Context, c client.Client, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nm *invv1alpha1.NodeModel, s *runtime.Scheme) (*corev1.ConfigMap, error) {
+	if nc.Spec.StartupConfig == nil {
+		return nil, nil
+	}
+	// the template config map lives in the namespace of the node
+	tmpl := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: *nc.Spec.StartupConfig}, tmpl); err != nil {
+		return nil, fmt.Errorf("cannot get startup config %s, err: %w", *nc.Spec.StartupConfig, err)
+	}
+
+	input, err := GetStartupConfigInput(ctx, c, cr, nc, nm)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]string, len(tmpl.Data))
+	for k, v := range tmpl.Data {
+		t, err := template.New(k).Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse startup config template %s, err: %w", k, err)
+		}
+		buf := new(bytes.Buffer)
+		if err := t.Execute(buf, input); err != nil {
+			return nil, fmt.Errorf("cannot render startup config template %s, err: %w", k, err)
+		}
+		data[k] = buf.String()
+	}
+
+	return NewStartupConfigMap(cr, data, s)
+}
+
+// NewStartupConfigMap returns the config map owned by the node that contains the
+// startup config data of the node.
+func NewStartupConfigMap(cr *invv1alpha1.Node, data map[string]string, s *runtime.Scheme) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.Identifier(),
+			Kind:       reflect.TypeOf(corev1.ConfigMap{}).Name(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cr.GetNamespace(),
+			Name:      GetStartupConfigMapName(cr.GetName()),
+			Labels: map[string]string{
+				invv1alpha1.NephioNodeNameKey: cr.GetName(),
+				WatchLabelKey:                 WatchLabelValue,
+			},
+		},
+		Data: data,
+	}
+	if err := ctrl.SetControllerReference(cr, cm, s); err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+// GetStartupConfigInput returns the data of the node that is available in the
+// startup config templates.
+func GetStartupConfigInput(ctx context.Context, c client.Client, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nm *invv1alpha1.NodeModel) (*StartupConfigInput, error) {
+	ips, err := getIPs(ctx, c, cr)
+	if err != nil {
+		return nil, err
+	}
+	input := &StartupConfigInput{
+		Name:       cr.GetName(),
+		Namespace:  cr.GetNamespace(),
+		Labels:     cr.GetLabels(),
+		Provider:   cr.Spec.Provider,
+		Interfaces: nm.Spec.Interfaces,
+		IPs:        ips,
+	}
+	if nc.Spec.Model != nil {
+		input.Model = *nc.Spec.Model
+	}
+	return input, nil
+}
+
+// getIPs returns the allocated prefixes of the ip claims of the node.
+// When the ipam is not installed no ips are returned.
+func getIPs(ctx context.Context, c client.Client, cr *invv1alpha1.Node) (map[string]string, error) {
+	claims := &unstructured.UnstructuredList{}
+	claims.SetGroupVersionKind(IPClaimGroupVersionKind.GroupVersion().WithKind(IPClaimGroupVersionKind.Kind + "List"))
+	opts := []client.ListOption{
+		client.InNamespace(cr.GetNamespace()),
+		client.MatchingLabels{
+			invv1alpha1.NephioNodeNameKey: cr.GetName(),
+		},
+	}
+	ips := map[string]string{}
+	if err := c.List(ctx, claims, opts...); err != nil {
+		if meta.IsNoMatchError(err) {
+			return ips, nil
+		}
+		return nil, fmt.Errorf("cannot list ip claims, err: %w", err)
+	}
+	for _, claim := range claims.Items {
+		prefix, found, err := unstructured.NestedString(claim.Object, "status", "prefix")
+		if err != nil || !found {
+			// the prefix is not yet allocated
+			continue
+		}
+		ips[strings.TrimPrefix(claim.GetName(), cr.GetName()+"-")] = prefix
+	}
+	return ips, nil
+}
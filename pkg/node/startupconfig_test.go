@@ -0,0 +1,137 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func getTestIPClaim(name, nodeName, prefix string) *unstructured.Unstructured {
+	claim := &unstructured.Unstructured{}
+	claim.SetGroupVersionKind(IPClaimGroupVersionKind)
+	claim.SetNamespace(testNamespace)
+	claim.SetName(name)
+	claim.SetLabels(map[string]string{invv1alpha1.NephioNodeNameKey: nodeName})
+	if prefix != "" {
+		claim.Object["status"] = map[string]any{"prefix": prefix}
+	}
+	return claim
+}
+
+func TestGetStartupConfig(t *testing.T) {
+	cases := map[string]struct {
+		template string
+		// noIPAM is true when the ip claim crd is not installed
+		noIPAM  bool
+		want    string
+		wantErr bool
+	}{
+		"Render": {
+			template: `hostname {{ .Name }} role {{ index .Labels "role" }} model {{ .Model }}
+{{- range .Interfaces }}
+interface {{ .Name }}
+{{- end }}
+system {{ .IPs.system }}`,
+			want: `hostname leaf1 role leaf model ixrd3
+interface e1-1
+interface e1-2
+system 10.0.0.1/32`,
+		},
+		"MissingKey": {
+			// the loopback ip claim has no prefix allocated yet
+			template: `loopback {{ .IPs.loopback }}`,
+			wantErr:  true,
+		},
+		"NoIPAM": {
+			template: `ips {{ len .IPs }}`,
+			noIPAM:   true,
+			want:     `ips 0`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			if err := invv1alpha1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			b := fake.NewClientBuilder().
+				WithScheme(s).
+				WithObjects(
+					&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "startup"},
+						Data:       map[string]string{"config": tc.template},
+					},
+					getTestIPClaim("leaf1-system", "leaf1", "10.0.0.1/32"),
+					getTestIPClaim("leaf1-loopback", "leaf1", ""),
+					getTestIPClaim("leaf2-system", "leaf2", "10.0.0.2/32"),
+				)
+			if tc.noIPAM {
+				b = b.WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+						if list.GetObjectKind().GroupVersionKind().Group == IPClaimGroupVersionKind.Group {
+							return &meta.NoKindMatchError{GroupKind: IPClaimGroupVersionKind.GroupKind()}
+						}
+						return c.List(ctx, list, opts...)
+					},
+				})
+			}
+			c := b.Build()
+
+			cr := &invv1alpha1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      "leaf1",
+					Labels:    map[string]string{"role": "leaf"},
+				},
+				Spec: invv1alpha1.NodeSpec{Provider: testProvider},
+			}
+			nc := &invv1alpha1.NodeConfig{
+				Spec: invv1alpha1.NodeConfigSpec{
+					Model:         pointer.String("ixrd3"),
+					StartupConfig: pointer.String("startup"),
+				},
+			}
+			nm := &invv1alpha1.NodeModel{
+				Spec: invv1alpha1.NodeModelSpec{
+					Interfaces: []invv1alpha1.NodeModelInterface{{Name: "e1-1"}, {Name: "e1-2"}},
+				},
+			}
+
+			got, err := GetStartupConfig(context.Background(), c, cr, nc, nm, s)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("want error, got: %v", got.Data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.GetName() != GetStartupConfigMapName(cr.GetName()) {
+				t.Errorf("want name %s, got: %s", GetStartupConfigMapName(cr.GetName()), got.GetName())
+			}
+			if !metav1.IsControlledBy(got, cr) {
+				t.Errorf("want config map controlled by the node")
+			}
+			if diff := cmp.Diff(tc.want, got.Data["config"]); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package node
+
+// Phase defines the lifecycle phase of a node.
+type Phase string
+
+const (
+	// PhasePending indicates the pod of the node is not yet scheduled
+	PhasePending Phase = "Pending"
+	// PhasePodScheduled indicates the pod is scheduled but the containers are not yet running
+	PhasePodScheduled Phase = "PodScheduled"
+	// PhaseBooting indicates the containers are running but the node is not yet ready
+	PhaseBooting Phase = "Booting"
+	// PhaseBootstrapping indicates the initial config is applied to the node
+	PhaseBootstrapping Phase = "Bootstrapping"
+	// PhaseReady indicates the node is ready
+	PhaseReady Phase = "Ready"
+	// PhaseDegraded indicates a node that was ready is no longer ready or
+	// that the initial config could not be applied
+	PhaseDegraded Phase = "Degraded"
+	// PhaseDeleting indicates the node is torn down before it is deleted
+	PhaseDeleting Phase = "Deleting"
+)
+
+// The status of the node api only contains conditions, the information below
+// is recorded in the annotations of the node as the interim status until the
+// inventory api has status fields for it. The annotations are only patched when
+// they change, such that a reconcile without a phase change does not write the node.
+const (
+	PhaseKey           = "node.nephio.com/phase"
+	PodNameKey         = "node.nephio.com/pod-name"
+	PodUIDKey          = "node.nephio.com/pod-uid"
+	PodIPsKey          = "node.nephio.com/pod-ips"
+	HostKey            = "node.nephio.com/host"
+	SoftwareVersionKey = "node.nephio.com/software-version"
+	ChassisKey         = "node.nephio.com/chassis"
+)
+
+// DeviceInfo defines the information that is read back from the device
+// after the initial config is applied.
+type DeviceInfo struct {
+	SoftwareVersion string
+	Chassis         string
+}
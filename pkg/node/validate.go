@@ -0,0 +1,72 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ValidateVariant returns an error when the model is not one of the variants in the
+// variants config map of the provider, see GetVariants.
+func ValidateVariant(ctx context.Context, c client.Client, namespace, name, model string) error {
+	variants, key, err := GetVariants(ctx, c, namespace, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("variants configmap %s not found in namespace %s or %s, create it with an entry for model %q",
+				name, namespace, os.Getenv("POD_NAMESPACE"), model)
+		}
+		return fmt.Errorf("cannot get variants configmap %s, err: %w", key, err)
+	}
+	if _, ok := variants.Data[model]; !ok {
+		return fmt.Errorf("model %q is not a variant in configmap %s, supported models are %q or add the model to the configmap",
+			model, key, strings.Join(getKeys(variants.Data), ", "))
+	}
+	return nil
+}
+
+// ValidateLicenseKey returns an error when the license key is not present in the
+// license secret of the provider.
+func ValidateLicenseKey(ctx context.Context, c client.Client, key types.NamespacedName, licenseKey string) error {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, key, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("license secret %s not found, create it with the license under key %q", key, licenseKey)
+		}
+		return fmt.Errorf("cannot get license secret %s, err: %w", key, err)
+	}
+	// the keys of the secret are not listed since they are returned to any user that
+	// can write a node config
+	if _, ok := secret.Data[licenseKey]; !ok {
+		return fmt.Errorf("license key %q not found in secret %s, add the license to the secret", licenseKey, key)
+	}
+	return nil
+}
+
+// ValidateStartupConfig returns an error when the startup config template
+// referenced by the node config does not exist in the namespace of the node.
+func ValidateStartupConfig(ctx context.Context, c client.Client, namespace, startupConfig string) error {
+	key := types.NamespacedName{Namespace: namespace, Name: startupConfig}
+	if err := c.Get(ctx, key, &corev1.ConfigMap{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("startup config configmap %s not found, create it in the namespace of the node", key)
+		}
+		return fmt.Errorf("cannot get startup config configmap %s, err: %w", key, err)
+	}
+	return nil
+}
+
+func getKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
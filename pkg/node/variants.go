@@ -0,0 +1,36 @@
+package node
+
+import (
+	"context"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetVariants returns the variants config map of a provider for a node in the namespace.
+// The config map in the namespace of the node is used, else the config map in the
+// namespace of the operator, which applies to the nodes in all namespaces. The key of
+// the config map that is used is returned, when the config map is not found the key
+// is in the namespace of the operator.
+func GetVariants(ctx context.Context, c client.Client, namespace, name string) (*corev1.ConfigMap, types.NamespacedName, error) {
+	namespaces := []string{namespace}
+	if namespace != os.Getenv("POD_NAMESPACE") {
+		namespaces = append(namespaces, os.Getenv("POD_NAMESPACE"))
+	}
+	var key types.NamespacedName
+	for _, ns := range namespaces {
+		key = types.NamespacedName{Namespace: ns, Name: name}
+		variants := &corev1.ConfigMap{}
+		err := c.Get(ctx, key, variants)
+		if err == nil {
+			return variants, key, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, key, err
+		}
+	}
+	return nil, key, apierrors.NewNotFound(corev1.Resource("configmaps"), name)
+}
@@ -0,0 +1,97 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func getTestVariants(namespace string, models ...string) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "test-variants"},
+		Data:       map[string]string{},
+	}
+	for _, model := range models {
+		cm.Data[model] = ""
+	}
+	return cm
+}
+
+func TestGetVariants(t *testing.T) {
+	cases := map[string]struct {
+		cms          []client.Object
+		namespace    string
+		model        string
+		wantKey      types.NamespacedName
+		wantNotFound bool
+		wantErr      bool
+	}{
+		"NodeNamespace": {
+			cms: []client.Object{
+				getTestVariants(testNamespace, "ixrd2"),
+				getTestVariants(testPodNamespace, "ixrd3"),
+			},
+			namespace: testNamespace,
+			model:     "ixrd2",
+			wantKey:   types.NamespacedName{Namespace: testNamespace, Name: "test-variants"},
+		},
+		"OperatorNamespace": {
+			cms:       []client.Object{getTestVariants(testPodNamespace, "ixrd3")},
+			namespace: testNamespace,
+			model:     "ixrd3",
+			wantKey:   types.NamespacedName{Namespace: testPodNamespace, Name: "test-variants"},
+		},
+		"UnknownModel": {
+			// the config map of the namespace of the node replaces the config map of
+			// the namespace of the operator
+			cms: []client.Object{
+				getTestVariants(testNamespace, "ixrd2"),
+				getTestVariants(testPodNamespace, "ixrd3"),
+			},
+			namespace: testNamespace,
+			model:     "ixrd3",
+			wantKey:   types.NamespacedName{Namespace: testNamespace, Name: "test-variants"},
+			wantErr:   true,
+		},
+		"NotFound": {
+			namespace:    testNamespace,
+			model:        "ixrd3",
+			wantKey:      types.NamespacedName{Namespace: testPodNamespace, Name: "test-variants"},
+			wantNotFound: true,
+			wantErr:      true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("POD_NAMESPACE", testPodNamespace)
+			s := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			c := fake.NewClientBuilder().WithScheme(s).WithObjects(tc.cms...).Build()
+
+			_, key, err := GetVariants(context.Background(), c, tc.namespace, "test-variants")
+			if diff := cmp.Diff(tc.wantKey, key); diff != "" {
+				t.Errorf("key -want, +got:\n%s", diff)
+			}
+			if apierrors.IsNotFound(err) != tc.wantNotFound {
+				t.Errorf("want not found %t, got: %v", tc.wantNotFound, err)
+			}
+
+			err = ValidateVariant(context.Background(), c, tc.namespace, "test-variants", tc.model)
+			if tc.wantErr != (err != nil) {
+				t.Errorf("want error %t, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
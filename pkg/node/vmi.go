@@ -0,0 +1,39 @@
+package node
+
+import (
+	"context"
+
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// VirtualMachineInstancePodNetworkName is the name of the network of the virtual
+// machine instance that connects the virtual machine to the pod network, the ips of
+// this interface are the ips of the node.
+const VirtualMachineInstancePodNetworkName = "default"
+
+// VirtualMachineInstanceGroupVersionKind is the gvk of the kubevirt virtual machine
+// instance. The virtual machine instances are handled as unstructured objects to
+// avoid a dependency on the kubevirt api.
+//
+//nolint:gochecknoglobals
+var VirtualMachineInstanceGroupVersionKind = schema.GroupVersionKind{
+	Group:   "kubevirt.io",
+	Version: "v1",
+	Kind:    "VirtualMachineInstance",
+}
+
+// VirtualMachineNode is implemented by the nodes of the vm provider type, which run
+// in a kubevirt virtual machine instance instead of a pod.
+type VirtualMachineNode interface {
+	GetVirtualMachineInstanceSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*unstructured.Unstructured, error)
+}
+
+// NewVirtualMachineInstance returns an empty virtual machine instance.
+func NewVirtualMachineInstance() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(VirtualMachineInstanceGroupVersionKind)
+	return u
+}
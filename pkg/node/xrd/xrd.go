@@ -0,0 +1,602 @@
+package xrd
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/cert"
+	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	"github.com/scrapli/scrapligo/driver/network"
+	"github.com/scrapli/scrapligo/driver/options"
+	"github.com/scrapli/scrapligo/platform"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	CiscoXRdProvider      = "xrd.cisco.com"
+	defaultXRdImageName   = "ios-xr/xrd-control-plane:latest"
+	defaultXRdModel       = "control-plane"
+	scrapliGoXRdKey       = "cisco_iosxr"
+	defaultInterfaceMTU   = 9000
+	containerInterfacePfx = "eth"
+	gnmiPort              = 57400
+
+	//
+	terminationGracePeriodSeconds = 0
+	startupInitialDelay           = 30
+	startupPeriodSeconds          = 10
+	startupFailureThreshold       = 30
+
+	// environment
+	xrInterfacesEnv      = "XR_INTERFACES"
+	xrMgmtInterfacesEnv  = "XR_MGMT_INTERFACES"
+	xrFirstBootConfigEnv = "XR_FIRST_BOOT_CONFIG"
+	xrEveryBootConfigEnv = "XR_EVERY_BOOT_CONFIG"
+	// the management interface snoops the ip and default route of the pod interface
+	xrMgmtInterfaces = "linux:eth0,xr_name=Mg0/RP0/CPU0/0,chksum,snoop_v4,snoop_v4_default_route"
+
+	// volumes
+	defaultSecretUserNameKey = "username"
+	defaultSecretPasswordKey = "password"
+	certificateProfileName   = "k8s-profile"
+	certificateDir           = "/misc/config/grpc"
+	firstBootVolName         = "first-boot-config"
+	firstBootVolMntPath      = "/etc/xrd/first-boot"
+	firstBootFileName        = "first-boot.cfg"
+	firstBootInitName        = "first-boot-config"
+	startupConfigVolName     = "startup-config"
+	startupConfigVolMntPath  = "/etc/xrd/startup"
+	startupConfigKey         = "startup.cfg"
+)
+
+var (
+	// the container limits cannot be set in the pod spec, so the memlock limit
+	// required by XR is raised before init is started
+	//nolint:gochecknoglobals
+	defaultCmd = []string{
+		"sh",
+		"-c",
+	}
+
+	//nolint:gochecknoglobals
+	defaultArgs = []string{
+		"ulimit -l unlimited && exec /sbin/init",
+	}
+
+	// defaultSysctls are the namespaced sysctls XR requires, they are unsafe sysctls
+	// that must be allowed on the kubelet with --allowed-unsafe-sysctls
+	//nolint:gochecknoglobals
+	defaultSysctls = []corev1.Sysctl{
+		{Name: "net.ipv4.ip_forward", Value: "1"},
+		{Name: "net.ipv6.conf.all.forwarding", Value: "1"},
+		{Name: "net.ipv6.conf.all.disable_ipv6", Value: "0"},
+	}
+
+	//nolint:gochecknoglobals
+	defaultResourceRequests = map[string]string{
+		"cpu":    "1",
+		"memory": "2Gi",
+	}
+	defaultResourceLimits = map[string]string{}
+
+	//nolint:gochecknoglobals
+	versionRegexp = regexp.MustCompile(`Cisco IOS XR Software, Version (\S+)`)
+	//nolint:gochecknoglobals
+	chassisRegexp = regexp.MustCompile(`(?m)^cisco (\S+)`)
+
+	// interfaceRegexp matches the interfaces of the node model that map to an XR
+	// interface, e.g. gi0-0-0-0
+	//nolint:gochecknoglobals
+	interfaceRegexp = regexp.MustCompile(`^[a-zA-Z]+[0-9]+(-[0-9]+)*$`)
+)
+
+// Register registers the node in the NodeRegistry.
+func Register(r node.NodeRegistry) {
+	r.Register(CiscoXRdProvider, func(c client.Client, s *runtime.Scheme) node.Node {
+		return &xrd{
+			Client: c,
+			scheme: s,
+		}
+	})
+}
+
+type xrd struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+func (r *xrd) GetProviderType(ctx context.Context) node.ProviderType {
+	return node.ProviderTypeNetwork
+}
+
+func (r *xrd) GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec {
+	return &invv1alpha1.NodeConfigSpec{
+		Provider: CiscoXRdProvider,
+		Model:    pointer.String(defaultXRdModel),
+		Image:    pointer.String(defaultXRdImageName),
+	}
+}
+
+// ValidateNodeConfig validates that the node model of the model exists, since XRd
+// has no variants.
+func (r *xrd) ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error {
+	if nc.Spec.Model == nil {
+		return nil
+	}
+	if _, err := r.GetNodeModel(ctx, nc); err != nil {
+		return fmt.Errorf("node model %s/%s of model %q not found, create it or use another model",
+			os.Getenv("POD_NAMESPACE"), getNodeModelName(nc), *nc.Spec.Model)
+	}
+	return nil
+}
+
+func (r *xrd) GetNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
+	// get nodeConfig by merging the provider defaults with the node config layers
+	return node.GetNodeConfig(ctx, r.Client, cr, r.GetNodeConfigDefaults(ctx))
+}
+
+func (r *xrd) GetNodeModelConfig(ctx context.Context, nc *invv1alpha1.NodeConfig) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: invv1alpha1.NodeKindAPIVersion,
+		Kind:       invv1alpha1.NodeModelKind,
+		Name:       getNodeModelName(nc),
+		Namespace:  os.Getenv("POD_NAMESPACE"),
+	}
+}
+
+func (r *xrd) GetNodeModel(ctx context.Context, nc *invv1alpha1.NodeConfig) (*invv1alpha1.NodeModel, error) {
+	nm := &invv1alpha1.NodeModel{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      getNodeModelName(nc),
+		Namespace: os.Getenv("POD_NAMESPACE"),
+	}, nm); err != nil {
+		return nil, err
+	}
+	return nm, nil
+}
+
+// GetNetworkAttachmentDefinitions returns a nad for every interface of the node model,
+// the interfaces are named ethN in the container in the order of the node model.
+// The interfaces of the node model must map to an XR interface, see getXRInterfaceName.
+func (r *xrd) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultXRdModel), err)
+	}
+	itfces := make([]node.Interface, 0, len(nm.Spec.Interfaces))
+	for i, itfce := range nm.Spec.Interfaces {
+		if _, err := getXRInterfaceName(itfce.Name); err != nil {
+			return nil, err
+		}
+		itfces = append(itfces, node.Interface{
+			Name:          itfce.Name,
+			ContainerName: fmt.Sprintf("%s%d", containerInterfacePfx, i+1),
+			MTU:           defaultInterfaceMTU,
+		})
+	}
+	return node.GetNetworkAttachmentDefinitions(cr, nc, itfces, r.scheme)
+}
+
+func (r *xrd) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
+	return node.GetPersistentVolumeClaims(cr, nc, r.scheme)
+}
+
+// GetStartupConfig returns the config map with the startup config of the node rendered
+// from the startup config templates referenced in the node config. The templates must
+// provide the startup.cfg that is applied by XR on every boot.
+func (r *xrd) GetStartupConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) (*corev1.ConfigMap, error) {
+	if nc.Spec.StartupConfig == nil {
+		return nil, nil
+	}
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultXRdModel), err)
+	}
+	cm, err := node.GetStartupConfig(ctx, r.Client, cr, nc, nm, r.scheme)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := cm.Data[startupConfigKey]; !ok {
+		return nil, fmt.Errorf("startup config %s does not provide %s", *nc.Spec.StartupConfig, startupConfigKey)
+	}
+	return cm, nil
+}
+
+func (r *xrd) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*corev1.Pod, error) {
+	nadAnnotation, err := nad.GetNadAnnotation(nads)
+	if err != nil {
+		return nil, err
+	}
+
+	startupConfig, err := r.GetStartupConfig(ctx, cr, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	xrInterfaces, err := getXRInterfaces(nads)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.GetName(),
+			Namespace: cr.GetNamespace(),
+		},
+		Spec: corev1.PodSpec{
+			InitContainers:                getInitContainers(nc),
+			Containers:                    getContainers(cr.GetName(), nc, xrInterfaces),
+			TerminationGracePeriodSeconds: pointer.Int64(terminationGracePeriodSeconds),
+			NodeSelector:                  map[string]string{},
+			Affinity:                      node.GetAffinity(cr.GetNamespace()),
+			SecurityContext: &corev1.PodSecurityContext{
+				Sysctls: defaultSysctls,
+			},
+			Volumes: getVolumes(cr.GetName(), nc),
+		},
+	}
+
+	// the nad annotation is part of the hash since the networks of a pod
+	// are only attached when the pod is created, the startup config data
+	// is part of the hash since it is only read when the pod starts
+	var startupConfigData map[string]string
+	if startupConfig != nil {
+		startupConfigData = startupConfig.Data
+	}
+	hashString, err := node.GetHash([]any{d.Spec, string(nadAnnotation), startupConfigData})
+	if err != nil {
+		return nil, err
+	}
+	if len(d.GetAnnotations()) == 0 {
+		d.ObjectMeta.Annotations = map[string]string{}
+	}
+	d.ObjectMeta.Annotations[invv1alpha1.RevisionHash] = hashString
+	d.ObjectMeta.Annotations[invv1alpha1.NephioWiringKey] = "true"
+	if os.Getenv("ENABLE_NAD") == "true" {
+		d.ObjectMeta.Annotations[nadv1.NetworkAttachmentAnnot] = string(nadAnnotation)
+	}
+
+	if len(d.GetLabels()) == 0 {
+		d.ObjectMeta.Labels = map[string]string{}
+	}
+	d.ObjectMeta.Labels[invv1alpha1.NephioTopologyKey] = cr.Namespace
+
+	if err := ctrl.SetControllerReference(cr, d, r.scheme); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// SetInitialConfig installs the certificate of the node and enables the gnmi server
+// with tls using the cli, since XR expects the certificates as files on the device.
+func (r *xrd) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	username, password, err := r.getCredentials(ctx, cr)
+	if err != nil {
+		return err
+	}
+	certData, err := r.getCertData(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	d, err := openDriver(ips[0].IP, username, password)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	for _, cmd := range getCertificateCommands(certData) {
+		resp, err := d.SendCommand(cmd)
+		if err != nil {
+			return err
+		}
+		if resp.Failed != nil {
+			return resp.Failed
+		}
+	}
+
+	resp, err := d.SendConfigs(getInitialConfig())
+	if err != nil {
+		return err
+	}
+	return resp.Failed
+}
+
+// GetInitialConfigHash returns the hash of the initial config, which includes the
+// certificate data, such that a rotation of the certificate changes the hash.
+func (r *xrd) GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error) {
+	certData, err := r.getCertData(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+	return node.GetHash([]any{getCertificateCommands(certData), getInitialConfig()})
+}
+
+// GetDeviceInfo reads the software version and the platform from the output of
+// show version.
+func (r *xrd) GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error) {
+	username, password, err := r.getCredentials(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	d, err := openDriver(ips[0].IP, username, password)
+	if err != nil {
+		return nil, err
+	}
+	defer d.Close()
+
+	resp, err := d.SendCommand("show version")
+	if err != nil {
+		return nil, err
+	}
+	info := &node.DeviceInfo{}
+	if m := versionRegexp.FindStringSubmatch(resp.Result); m != nil {
+		info.SoftwareVersion = m[1]
+	}
+	if m := chassisRegexp.FindStringSubmatch(resp.Result); m != nil {
+		info.Chassis = m[1]
+	}
+	return info, nil
+}
+
+// Teardown is not applicable, XR commits the config to the configuration database
+// on every commit.
+func (r *xrd) Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	return nil
+}
+
+// openDriver opens an XR cli session to the node.
+func openDriver(address, username, password string) (*network.Driver, error) {
+	p, err := platform.NewPlatform(
+		scrapliGoXRdKey,
+		address,
+		options.WithAuthNoStrictKey(),
+		options.WithAuthUsername(username),
+		options.WithAuthPassword(password),
+	)
+	if err != nil {
+		return nil, err
+	}
+	d, err := p.GetNetworkDriver()
+	if err != nil {
+		return nil, err
+	}
+	d.Channel.TimeoutOps = 10 * time.Second
+	if err := d.Open(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (r *xrd) getCredentials(ctx context.Context, cr *invv1alpha1.Node) (string, string, error) {
+	secret := &corev1.Secret{}
+	// we assume right now the default secret name is equal to the provider
+	// this provider username and password
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: CiscoXRdProvider}, secret); err != nil {
+		return "", "", err
+	}
+	return string(secret.Data[defaultSecretUserNameKey]), string(secret.Data[defaultSecretPasswordKey]), nil
+}
+
+func (r *xrd) getCertData(ctx context.Context, cr *invv1alpha1.Node) (*cert.CertData, error) {
+	certSecret := &corev1.Secret{}
+	// this is used to provide certificate for the gnmi server on the device
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}, certSecret); err != nil {
+		return nil, err
+	}
+	return cert.GetCertificateData(certSecret, certificateProfileName)
+}
+
+// getCertificateCommands returns the commands that write the certificate, the key and
+// the trust anchor of the grpc server to the grpc directory of XR. The pem data is
+// base64 encoded to send it on a single line of the cli.
+func getCertificateCommands(certData *cert.CertData) []string {
+	files := []struct {
+		name string
+		data string
+	}{
+		{name: "ems.pem", data: certData.Cert},
+		{name: "ems.key", data: certData.Key},
+		{name: "ca.cert", data: certData.CA},
+	}
+	cmds := make([]string, 0, len(files))
+	for _, f := range files {
+		cmds = append(cmds, fmt.Sprintf("run echo %s | base64 -d > %s/%s",
+			base64.StdEncoding.EncodeToString([]byte(f.data)), certificateDir, f.name))
+	}
+	return cmds
+}
+
+// getInitialConfig returns the initial config commands of the node, which enable
+// lldp and the gnmi server with tls.
+func getInitialConfig() []string {
+	return []string{
+		"lldp",
+		"grpc",
+		fmt.Sprintf("port %d", gnmiPort),
+		"tls",
+		"commit",
+	}
+}
+
+// getXRInterfaces returns the XR_INTERFACES of the nads, which map the interfaces
+// of the container to the XR interfaces. The XR interface is derived from the
+// interface of the node model, e.g. gi0-0-0-0 -> Gi0/0/0/0.
+func getXRInterfaces(nads []*nadv1.NetworkAttachmentDefinition) (string, error) {
+	itfces := make([]string, 0, len(nads))
+	for _, n := range nads {
+		containerName, err := nad.GetInterfaceName(n)
+		if err != nil {
+			return "", err
+		}
+		xrName, err := getXRInterfaceName(n.GetLabels()[invv1alpha1.NephioInterfaceNameKey])
+		if err != nil {
+			return "", err
+		}
+		itfces = append(itfces, fmt.Sprintf("linux:%s,xr_name=%s", containerName, xrName))
+	}
+	return strings.Join(itfces, ";"), nil
+}
+
+// getXRInterfaceName returns the XR interface of the interface of the node model,
+// the interface is a type followed by its dash separated location, e.g. gi0-0-0-0.
+func getXRInterfaceName(name string) (string, error) {
+	if !interfaceRegexp.MatchString(name) {
+		return "", fmt.Errorf("interface %q of the node model is not an XR interface, e.g. gi0-0-0-0", name)
+	}
+	xrName := strings.ReplaceAll(name, "-", "/")
+	return strings.ToUpper(xrName[:1]) + xrName[1:], nil
+}
+
+func getNodeModelName(nc *invv1alpha1.NodeConfig) string {
+	return fmt.Sprintf("%s-%s", CiscoXRdProvider, nc.GetModel(defaultXRdModel))
+}
+
+func getContainers(name string, nc *invv1alpha1.NodeConfig, xrInterfaces string) []corev1.Container {
+	env := []corev1.EnvVar{
+		{Name: xrInterfacesEnv, Value: xrInterfaces},
+		{Name: xrMgmtInterfacesEnv, Value: xrMgmtInterfaces},
+		{Name: xrFirstBootConfigEnv, Value: fmt.Sprintf("%s/%s", firstBootVolMntPath, firstBootFileName)},
+	}
+	if nc.Spec.StartupConfig != nil {
+		env = append(env, corev1.EnvVar{Name: xrEveryBootConfigEnv, Value: fmt.Sprintf("%s/%s", startupConfigVolMntPath, startupConfigKey)})
+	}
+	return []corev1.Container{{
+		Name:            name,
+		Image:           nc.GetImage(defaultXRdImageName),
+		Command:         defaultCmd,
+		Args:            defaultArgs,
+		Env:             env,
+		Resources:       nc.GetResourceRequirements(defaultResourceRequests, defaultResourceLimits),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &corev1.SecurityContext{
+			Privileged: pointer.Bool(true),
+			RunAsUser:  pointer.Int64(0),
+		},
+		TTY:          true,
+		Stdin:        true,
+		VolumeMounts: getVolumeMounts(nc),
+		StartupProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{
+						"/pkg/bin/xr_cli", "show version",
+					},
+				},
+			},
+			InitialDelaySeconds: startupInitialDelay,
+			PeriodSeconds:       startupPeriodSeconds,
+			FailureThreshold:    startupFailureThreshold,
+		},
+	}}
+}
+
+// getInitContainers returns an init container that renders the first boot config with
+// the credentials of the provider secret, such that the credentials are not stored in
+// a config map. XR requires a user before ssh can be used to bootstrap the node.
+func getInitContainers(nc *invv1alpha1.NodeConfig) []corev1.Container {
+	return []corev1.Container{{
+		Name:    firstBootInitName,
+		Image:   nc.GetImage(defaultXRdImageName),
+		Command: []string{"sh", "-c"},
+		Args: []string{fmt.Sprintf(
+			`printf 'username %%s\n group root-lr\n group cisco-support\n secret 0 %%s\n!\nssh server v2\n!\n' "$USERNAME" "$PASSWORD" > %s/%s`,
+			firstBootVolMntPath, firstBootFileName)},
+		Env: []corev1.EnvVar{
+			getSecretEnv("USERNAME", defaultSecretUserNameKey),
+			getSecretEnv("PASSWORD", defaultSecretPasswordKey),
+		},
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser: pointer.Int64(0),
+		},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      firstBootVolName,
+			MountPath: firstBootVolMntPath,
+		}},
+	}}
+}
+
+func getSecretEnv(name, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: CiscoXRdProvider,
+				},
+				Key: key,
+			},
+		},
+	}
+}
+
+func getVolumes(name string, nc *invv1alpha1.NodeConfig) []corev1.Volume {
+	vols := []corev1.Volume{
+		{
+			Name:         firstBootVolName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+	}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vols = append(vols, corev1.Volume{
+			Name: pv.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: node.GetPersistentVolumeClaimName(name, pv.Name),
+				},
+			},
+		})
+	}
+	if nc.Spec.StartupConfig != nil {
+		vols = append(vols, corev1.Volume{
+			Name: startupConfigVolName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: node.GetStartupConfigMapName(name),
+					},
+				},
+			},
+		})
+	}
+	return vols
+}
+
+func getVolumeMounts(nc *invv1alpha1.NodeConfig) []corev1.VolumeMount {
+	vms := []corev1.VolumeMount{
+		{
+			Name:      firstBootVolName,
+			MountPath: firstBootVolMntPath,
+			ReadOnly:  true,
+		},
+	}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vms = append(vms, corev1.VolumeMount{
+			Name:      pv.Name,
+			MountPath: pv.MountPath,
+		})
+	}
+	if nc.Spec.StartupConfig != nil {
+		vms = append(vms, corev1.VolumeMount{
+			Name:      startupConfigVolName,
+			MountPath: startupConfigVolMntPath,
+			ReadOnly:  true,
+		})
+	}
+	return vms
+}
@@ -0,0 +1,128 @@
+package xrd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetXRInterfaceName(t *testing.T) {
+	cases := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"GigabitEthernet": {
+			input: "gi0-0-0-0",
+			want:  "Gi0/0/0/0",
+		},
+		"MultiDigit": {
+			input: "hundredgige0-0-0-12",
+			want:  "Hundredgige0/0/0/12",
+		},
+		"NoLocation": {
+			input: "loopback0",
+			want:  "Loopback0",
+		},
+		"Empty": {
+			input:   "",
+			wantErr: true,
+		},
+		"NoType": {
+			input:   "0-0-0-0",
+			wantErr: true,
+		},
+		"TrailingDash": {
+			input:   "gi0-0-",
+			wantErr: true,
+		},
+		"DoubleDash": {
+			input:   "gi0--0",
+			wantErr: true,
+		},
+		"Slashes": {
+			input:   "Gi0/0/0/0",
+			wantErr: true,
+		},
+		"OtherScheme": {
+			input: "e1-1",
+			want:  "E1/1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := getXRInterfaceName(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("want error, got: %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetXRInterfaces(t *testing.T) {
+	cases := map[string]struct {
+		input   []node.Interface
+		want    string
+		wantErr bool
+	}{
+		"None": {
+			want: "",
+		},
+		"Multiple": {
+			input: []node.Interface{
+				{Name: "gi0-0-0-0", ContainerName: "eth1"},
+				{Name: "gi0-0-0-2", ContainerName: "eth3"},
+			},
+			want: "linux:eth1,xr_name=Gi0/0/0/0;linux:eth3,xr_name=Gi0/0/0/2",
+		},
+		"Malformed": {
+			input: []node.Interface{
+				{Name: "gi0-0-0-0", ContainerName: "eth1"},
+				{Name: "gi0_0_0_1", ContainerName: "eth2"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := invv1alpha1.AddToScheme(s); err != nil {
+				t.Fatal(err)
+			}
+			cr := &invv1alpha1.Node{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1"}}
+			nads, err := node.GetWireNetworkAttachmentDefinitions(cr, tc.input, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := getXRInterfaces(nads)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("want error, got: %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,9 +2,12 @@ package xserver
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"os"
 
+	"github.com/henderiw-nephio/network-node-operator/pkg/nad"
 	"github.com/henderiw-nephio/network-node-operator/pkg/node"
 	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
@@ -12,15 +15,44 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 )
 
 const (
-	ServerProvider       = "x.server.com"
-	defaultServerVariant = "server1"
-	variantsCfgMapName   = "x.server.com-variants"
+	ServerProvider          = "x.server.com"
+	defaultServerVariant    = "server1"
+	defaultServerImageName  = "ghcr.io/hellt/network-multitool:latest"
+	variantsCfgMapName      = "x.server.com-variants"
+	terminationGracePeriods = 0
+	podAffinityWeight       = 100
 )
 
+var (
+	//nolint:gochecknoglobals
+	defaultResourceRequests = map[string]string{
+		"cpu":    "100m",
+		"memory": "128Mi",
+	}
+	defaultResourceLimits = map[string]string{}
+)
+
+// variant defines the container of the server as provided in the variants configmap.
+type variant struct {
+	// Image used for the server container, the image of the node config takes precedence
+	Image string `json:"image,omitempty"`
+	// Command of the server container
+	Command []string `json:"command,omitempty"`
+	// Args of the server container
+	Args []string `json:"args,omitempty"`
+	// Requests defines the key/value resource requests e.g. cpu, memory
+	Requests map[string]string `json:"requests,omitempty"`
+	// Limits defines the key/value resource limits e.g. cpu, memory
+	Limits map[string]string `json:"limits,omitempty"`
+}
+
 // Register registers the node in the NodeRegistry.
 func Register(r node.NodeRegistry) {
 	r.Register(ServerProvider, func(c client.Client, s *runtime.Scheme) node.Node {
@@ -40,15 +72,33 @@ func (r *server) GetProviderType(ctx context.Context) node.ProviderType {
 	return node.ProviderTypeServer
 }
 
+// GetNodeConfigDefaults returns the provider defaults of the node config. The image
+// is not defaulted since the image of the variant takes precedence over the default image.
+func (r *server) GetNodeConfigDefaults(ctx context.Context) *invv1alpha1.NodeConfigSpec {
+	return &invv1alpha1.NodeConfigSpec{
+		Provider: ServerProvider,
+		Model:    pointer.String(defaultServerVariant),
+	}
+}
+
+// ValidateNodeConfig validates the model against the variants of the namespace
+// of the node, see node.GetVariants.
+func (r *server) ValidateNodeConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) error {
+	if nc.Spec.Model == nil {
+		return nil
+	}
+	return node.ValidateVariant(ctx, r.Client, cr.GetNamespace(), variantsCfgMapName, *nc.Spec.Model)
+}
+
 func (r *server) GetNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
-	// get nodeConfig via paramRef
-	nodeConfig, err := r.getNodeConfig(ctx, cr)
+	// get nodeConfig by merging the provider defaults with the node config layers
+	nodeConfig, err := node.GetNodeConfig(ctx, r.Client, cr, r.GetNodeConfigDefaults(ctx))
 	if err != nil {
 		return nil, err
 	}
 
 	// validate if the model returned exists in the variant list
-	if err := r.checkVariants(ctx, cr, nodeConfig.GetModel(defaultServerVariant)); err != nil {
+	if _, err := r.getVariant(ctx, cr, nodeConfig.GetModel(defaultServerVariant)); err != nil {
 		return nil, err
 	}
 	return nodeConfig, nil
@@ -75,77 +125,200 @@ func (r *server) GetNodeModel(ctx context.Context, nc *invv1alpha1.NodeConfig) (
 }
 
 func (r *server) GetNetworkAttachmentDefinitions(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*nadv1.NetworkAttachmentDefinition, error) {
-	// todo check node model and get interfaces from the model
-	nads := []*nadv1.NetworkAttachmentDefinition{}
-	return nads, nil
+	nm, err := r.GetNodeModel(ctx, nc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node model for model %s, err: %w", nc.GetModel(defaultServerVariant), err)
+	}
+	itfces := make([]node.Interface, 0, len(nm.Spec.Interfaces))
+	for _, itfce := range nm.Spec.Interfaces {
+		// the mtu is not set such that the default mtu of the wire cni is used
+		itfces = append(itfces, node.Interface{
+			Name: itfce.Name,
+		})
+	}
+	return node.GetNetworkAttachmentDefinitions(cr, nc, itfces, r.scheme)
 }
 
 func (r *server) GetPersistentVolumeClaims(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) ([]*corev1.PersistentVolumeClaim, error) {
-	// todo check node model and get interfaces from the model
-	pvcs := []*corev1.PersistentVolumeClaim{}
-	return pvcs, nil
+	return node.GetPersistentVolumeClaims(cr, nc, r.scheme)
+}
+
+// GetStartupConfig returns no startup config since a server has no startup config.
+func (r *server) GetStartupConfig(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig) (*corev1.ConfigMap, error) {
+	return nil, nil
 }
 
 func (r *server) GetPodSpec(ctx context.Context, cr *invv1alpha1.Node, nc *invv1alpha1.NodeConfig, nads []*nadv1.NetworkAttachmentDefinition) (*corev1.Pod, error) {
-	d := &corev1.Pod{}
+	nadAnnotation, err := nad.GetNadAnnotation(nads)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := r.getVariant(ctx, cr, nc.GetModel(defaultServerVariant))
+	if err != nil {
+		return nil, err
+	}
+
+	d := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.GetName(),
+			Namespace: cr.GetNamespace(),
+		},
+		Spec: corev1.PodSpec{
+			Containers:                    getContainers(cr.GetName(), nc, v),
+			TerminationGracePeriodSeconds: pointer.Int64(terminationGracePeriods),
+			NodeSelector:                  map[string]string{},
+			Affinity:                      getAffinity(cr.GetNamespace()),
+			Volumes:                       getVolumes(cr.GetName(), nc),
+		},
+	}
+
+	// the nad annotation is part of the hash since the networks of a pod
+	// are only attached when the pod is created
+	hashString := getHash([]any{d.Spec, string(nadAnnotation)})
+	if len(d.GetAnnotations()) == 0 {
+		d.ObjectMeta.Annotations = map[string]string{}
+	}
+	d.ObjectMeta.Annotations[invv1alpha1.RevisionHash] = hashString
+	d.ObjectMeta.Annotations[invv1alpha1.NephioWiringKey] = "true"
+	if os.Getenv("ENABLE_NAD") == "true" {
+		d.ObjectMeta.Annotations[nadv1.NetworkAttachmentAnnot] = string(nadAnnotation)
+	}
+
+	if len(d.GetLabels()) == 0 {
+		d.ObjectMeta.Labels = map[string]string{}
+	}
+	d.ObjectMeta.Labels[invv1alpha1.NephioTopologyKey] = cr.Namespace
+
+	if err := ctrl.SetControllerReference(cr, d, r.scheme); err != nil {
+		return nil, err
+	}
 	return d, nil
 }
 
+// SetInitialConfig is not applicable for a server, since servers don't require
+// a bootstrap config.
 func (r *server) SetInitialConfig(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
 	return nil
+}
 
+// Teardown is not applicable for a server, the pod and its resources are deleted
+// by the controller.
+func (r *server) Teardown(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) error {
+	return nil
 }
 
-func (r *server) getNodeConfig(ctx context.Context, cr *invv1alpha1.Node) (*invv1alpha1.NodeConfig, error) {
-	if cr.Spec.NodeConfig != nil && cr.Spec.NodeConfig.Name != "" {
-		nc := &invv1alpha1.NodeConfig{}
-		if err := r.Get(ctx, types.NamespacedName{Name: cr.Spec.NodeConfig.Name, Namespace: os.Getenv("POD_NAMESPACE")}, nc); err != nil {
-			return nil, err
-		}
-		return nc, nil
+// GetDeviceInfo returns no device information since a server is not bootstrapped.
+func (r *server) GetDeviceInfo(ctx context.Context, cr *invv1alpha1.Node, ips []corev1.PodIP) (*node.DeviceInfo, error) {
+	return nil, nil
+}
+
+// GetInitialConfigHash returns an empty hash since a server has no initial config.
+func (r *server) GetInitialConfigHash(ctx context.Context, cr *invv1alpha1.Node) (string, error) {
+	return "", nil
+}
 
+// getVariant returns the variant of the model from the variants configmap.
+// An empty variant uses the defaults of the server.
+func (r *server) getVariant(ctx context.Context, cr *invv1alpha1.Node, model string) (*variant, error) {
+	variants, _, err := node.GetVariants(ctx, r.Client, cr.GetNamespace(), variantsCfgMapName)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := variants.Data[model]
+	if !ok {
+		return nil, fmt.Errorf("cannot deploy pod, variant not provided in the configmap, got: %s", model)
 	}
-	// the nodeConfig was not provided, we list all nodeConfigs in the cr namespace
-	// we check if there is a nodeconfig with the name equal to the cr name + the provider matches
-	// if still not found we look at a nodeconfig with name default that matches the provider
-	// if still not found we return an empty nodeConfig, which populates the defaults
+	v := &variant{}
+	if err := yaml.Unmarshal([]byte(data), v); err != nil {
+		return nil, fmt.Errorf("cannot deploy pod, invalid variant %s in the configmap, err: %w", model, err)
+	}
+	return v, nil
+}
 
-	opts := []client.ListOption{
-		client.InNamespace(cr.GetNamespace()),
+func getContainers(name string, nc *invv1alpha1.NodeConfig, v *variant) []corev1.Container {
+	image := defaultServerImageName
+	if v.Image != "" {
+		image = v.Image
 	}
-	ncl := &invv1alpha1.NodeConfigList{}
-	if err := r.List(ctx, ncl, opts...); err != nil {
-		return nil, err
+	requests := defaultResourceRequests
+	if len(v.Requests) != 0 {
+		requests = v.Requests
+	}
+	limits := defaultResourceLimits
+	if len(v.Limits) != 0 {
+		limits = v.Limits
 	}
 
-	for _, nc := range ncl.Items {
-		// if there is a nodeconfig with the exact name of the node -> we return this nodeConfig
-		if nc.GetName() == cr.GetName() && cr.Spec.Provider == nc.Spec.Provider {
-			return &nc, nil
-		}
+	return []corev1.Container{{
+		Name:            name,
+		Image:           nc.GetImage(image),
+		Command:         v.Command,
+		Args:            v.Args,
+		Resources:       nc.GetResourceRequirements(requests, limits),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		SecurityContext: &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{
+				Add: []corev1.Capability{"NET_ADMIN", "NET_RAW"},
+			},
+		},
+		VolumeMounts: getVolumeMounts(nc),
+	}}
+}
+
+func getAffinity(topology string) *corev1.Affinity {
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: podAffinityWeight,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{
+								Key:      invv1alpha1.NephioTopologyKey,
+								Operator: "In",
+								Values:   []string{topology},
+							}},
+						},
+						TopologyKey: "kubernetes.io/hostname",
+					},
+				},
+			},
+		},
 	}
-	for _, nc := range ncl.Items {
-		// if there is a nodeconfig with the name default -> we return this nodeConfig
-		if nc.GetName() == "default" && cr.Spec.Provider == nc.Spec.Provider {
-			return &nc, nil
-		}
+}
 
+func getVolumes(name string, nc *invv1alpha1.NodeConfig) []corev1.Volume {
+	vols := []corev1.Volume{}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vols = append(vols, corev1.Volume{
+			Name: pv.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: node.GetPersistentVolumeClaimName(name, pv.Name),
+				},
+			},
+		})
 	}
-	// if nothing is found we return an empty nodeconfig
-	return &invv1alpha1.NodeConfig{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: os.Getenv("POD_NAMESPACE"),
-		},
-	}, nil
+	return vols
 }
 
-func (r *server) checkVariants(ctx context.Context, cr *invv1alpha1.Node, model string) error {
-	variants := &corev1.ConfigMap{}
-	if err := r.Get(ctx, types.NamespacedName{Name: variantsCfgMapName, Namespace: os.Getenv("POD_NAMESPACE")}, variants); err != nil {
-		return err
+func getVolumeMounts(nc *invv1alpha1.NodeConfig) []corev1.VolumeMount {
+	vms := []corev1.VolumeMount{}
+	for _, pv := range nc.Spec.PersistentVolumes {
+		vms = append(vms, corev1.VolumeMount{
+			Name:      pv.Name,
+			MountPath: pv.MountPath,
+		})
 	}
-	if _, ok := variants.Data[model]; !ok {
-		return fmt.Errorf("cannot deploy pod, variant not provided in the configmap, got: %s", model)
+	return vms
+}
+
+func getHash(x any) string {
+	b, err := json.Marshal(x)
+	if err != nil {
+		panic(err)
 	}
-	return nil
+	hash := sha256.Sum256(b)
+	return fmt.Sprintf("%x", hash)
 }
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// nodeValidator rejects the nodes that cannot be deployed by their provider
+type nodeValidator struct {
+	client.Client
+	scheme       *runtime.Scheme
+	nodeRegistry node.NodeRegistry
+}
+
+func (r *nodeValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cr, ok := obj.(*invv1alpha1.Node)
+	if !ok {
+		return nil, fmt.Errorf("expecting a node, got: %T", obj)
+	}
+	return nil, r.validate(ctx, cr)
+}
+
+// ValidateUpdate only validates the node when the spec or the labels change, such that
+// the status updates and the finalizer removal of the controller are never rejected.
+func (r *nodeValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldCr, ok := oldObj.(*invv1alpha1.Node)
+	if !ok {
+		return nil, fmt.Errorf("expecting a node, got: %T", oldObj)
+	}
+	cr, ok := newObj.(*invv1alpha1.Node)
+	if !ok {
+		return nil, fmt.Errorf("expecting a node, got: %T", newObj)
+	}
+	if cr.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+	if equality.Semantic.DeepEqual(oldCr.Spec, cr.Spec) && equality.Semantic.DeepEqual(oldCr.GetLabels(), cr.GetLabels()) {
+		return nil, nil
+	}
+	return nil, r.validate(ctx, cr)
+}
+
+func (r *nodeValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks that the provider of the node is supported, that the referenced
+// node config exists and that the resolved node config is valid for the provider.
+func (r *nodeValidator) validate(ctx context.Context, cr *invv1alpha1.Node) error {
+	n, err := r.nodeRegistry.NewNodeOfProvider(cr.Spec.Provider, r.Client, r.scheme)
+	if err != nil {
+		return fmt.Errorf("invalid node %s, spec.provider: %w", cr.GetName(), err)
+	}
+
+	if cr.Spec.NodeConfig != nil && cr.Spec.NodeConfig.Name != "" {
+		nc := &invv1alpha1.NodeConfig{}
+		key := types.NamespacedName{Namespace: os.Getenv("POD_NAMESPACE"), Name: cr.Spec.NodeConfig.Name}
+		if err := r.Get(ctx, key, nc); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("invalid node %s, spec.nodeConfig: node config %s not found, create it or remove the reference", cr.GetName(), key)
+			}
+			return fmt.Errorf("cannot get node config %s, err: %w", key, err)
+		}
+		if nc.Spec.Provider != cr.Spec.Provider {
+			return fmt.Errorf("invalid node %s, spec.nodeConfig: node config %s is of provider %q, expected provider %q",
+				cr.GetName(), key, nc.Spec.Provider, cr.Spec.Provider)
+		}
+	}
+
+	nc, err := node.GetNodeConfig(ctx, r.Client, cr, n.GetNodeConfigDefaults(ctx))
+	if err != nil {
+		return fmt.Errorf("cannot resolve the node config of node %s, err: %w", cr.GetName(), err)
+	}
+	if err := n.ValidateNodeConfig(ctx, cr, nc); err != nil {
+		return fmt.Errorf("invalid node config of node %s from %s: %w", cr.GetName(), nc.GetAnnotations()[node.NodeConfigSourcesKey], err)
+	}
+	if nc.Spec.StartupConfig != nil {
+		if err := node.ValidateStartupConfig(ctx, r.Client, cr.GetNamespace(), *nc.Spec.StartupConfig); err != nil {
+			return fmt.Errorf("invalid node config of node %s from %s: %w", cr.GetName(), nc.GetAnnotations()[node.NodeConfigSourcesKey], err)
+		}
+	}
+	return nil
+}
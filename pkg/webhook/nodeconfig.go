@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// nodeConfigDefaulter defaults the provider fields of the node configs
+type nodeConfigDefaulter struct {
+	client.Client
+	scheme       *runtime.Scheme
+	nodeRegistry node.NodeRegistry
+}
+
+// Default fills the unset model and image of the default node config in the namespace
+// of the operator with the provider defaults. This node config is the base layer of
+// the node configs of the provider, the other node configs are overlays that are not
+// defaulted since their fields overwrite the fields of the layers below.
+func (r *nodeConfigDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	nc, ok := obj.(*invv1alpha1.NodeConfig)
+	if !ok {
+		return fmt.Errorf("expecting a node config, got: %T", obj)
+	}
+	if nc.GetName() != node.DefaultNodeConfigName || nc.GetNamespace() != os.Getenv("POD_NAMESPACE") {
+		return nil
+	}
+	n, err := r.nodeRegistry.NewNodeOfProvider(nc.Spec.Provider, r.Client, r.scheme)
+	if err != nil {
+		// the validating webhook rejects the unsupported provider
+		return nil
+	}
+	defaults := n.GetNodeConfigDefaults(ctx)
+	if nc.Spec.Model == nil {
+		nc.Spec.Model = defaults.Model
+	}
+	if nc.Spec.Image == nil {
+		nc.Spec.Image = defaults.Image
+	}
+	return nil
+}
+
+// nodeConfigValidator rejects the node configs that cannot be used by their provider
+type nodeConfigValidator struct {
+	client.Client
+	scheme       *runtime.Scheme
+	nodeRegistry node.NodeRegistry
+}
+
+func (r *nodeConfigValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	nc, ok := obj.(*invv1alpha1.NodeConfig)
+	if !ok {
+		return nil, fmt.Errorf("expecting a node config, got: %T", obj)
+	}
+	return nil, r.validate(ctx, nc)
+}
+
+// ValidateUpdate only validates the node config when the spec or the annotations change.
+func (r *nodeConfigValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldNc, ok := oldObj.(*invv1alpha1.NodeConfig)
+	if !ok {
+		return nil, fmt.Errorf("expecting a node config, got: %T", oldObj)
+	}
+	nc, ok := newObj.(*invv1alpha1.NodeConfig)
+	if !ok {
+		return nil, fmt.Errorf("expecting a node config, got: %T", newObj)
+	}
+	if nc.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+	if equality.Semantic.DeepEqual(oldNc.Spec, nc.Spec) && equality.Semantic.DeepEqual(oldNc.GetAnnotations(), nc.GetAnnotations()) {
+		return nil, nil
+	}
+	return nil, r.validate(ctx, nc)
+}
+
+func (r *nodeConfigValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks that the provider of the node config is supported, that the node
+// selector, the interface plugins and the persistent volumes are valid and that the node config is valid for the nodes it applies to.
+func (r *nodeConfigValidator) validate(ctx context.Context, nc *invv1alpha1.NodeConfig) error {
+	n, err := r.nodeRegistry.NewNodeOfProvider(nc.Spec.Provider, r.Client, r.scheme)
+	if err != nil {
+		return fmt.Errorf("invalid node config %s, spec.provider: %w", nc.GetName(), err)
+	}
+	if _, err := node.SelectsNode(nc, &invv1alpha1.Node{}); err != nil {
+		return fmt.Errorf("invalid node config %s, annotation %s: %w", nc.GetName(), node.NodeSelectorKey, err)
+	}
+	if _, err := node.GetInterfacePlugins(nc); err != nil {
+		return fmt.Errorf("invalid node config %s, annotation %s: %w", nc.GetName(), node.InterfacePluginsKey, err)
+	}
+	if _, err := node.GetPersistentVolumeStorage(nc); err != nil {
+		return fmt.Errorf("invalid node config %s, annotation %s: %w", nc.GetName(), node.PersistentVolumesKey, err)
+	}
+
+	// the node configs in the namespace of the operator apply to the nodes in all namespaces
+	opts := []client.ListOption{}
+	if nc.GetNamespace() != os.Getenv("POD_NAMESPACE") {
+		opts = append(opts, client.InNamespace(nc.GetNamespace()))
+	}
+	nodes := &invv1alpha1.NodeList{}
+	if err := r.List(ctx, nodes, opts...); err != nil {
+		return fmt.Errorf("cannot list nodes, err: %w", err)
+	}
+	for _, cr := range nodes.Items {
+		cr := cr
+		applies, err := node.AppliesToNode(nc, &cr)
+		if err != nil {
+			return err
+		}
+		if !applies {
+			continue
+		}
+		key := types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}
+		if err := n.ValidateNodeConfig(ctx, &cr, nc); err != nil {
+			return fmt.Errorf("invalid node config %s for node %s: %w", nc.GetName(), key, err)
+		}
+		if nc.Spec.StartupConfig != nil {
+			if err := node.ValidateStartupConfig(ctx, r.Client, cr.GetNamespace(), *nc.Spec.StartupConfig); err != nil {
+				return fmt.Errorf("invalid node config %s for node %s: %w", nc.GetName(), key, err)
+			}
+		}
+	}
+	return nil
+}
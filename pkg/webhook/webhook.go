@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Setup registers the validating webhook of the nodes and the defaulting and
+// validating webhooks of the node configs with the webhook server of the manager.
+func Setup(mgr ctrl.Manager, nodeRegistry node.NodeRegistry) error {
+	if err := invv1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
+		return err
+	}
+
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&invv1alpha1.Node{}).
+		WithValidator(&nodeValidator{
+			Client:       mgr.GetClient(),
+			scheme:       mgr.GetScheme(),
+			nodeRegistry: nodeRegistry,
+		}).
+		Complete(); err != nil {
+		return err
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&invv1alpha1.NodeConfig{}).
+		WithDefaulter(&nodeConfigDefaulter{
+			Client:       mgr.GetClient(),
+			scheme:       mgr.GetScheme(),
+			nodeRegistry: nodeRegistry,
+		}).
+		WithValidator(&nodeConfigValidator{
+			Client:       mgr.GetClient(),
+			scheme:       mgr.GetScheme(),
+			nodeRegistry: nodeRegistry,
+		}).
+		Complete()
+}
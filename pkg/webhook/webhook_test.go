@@ -0,0 +1,292 @@
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/henderiw-nephio/network-node-operator/pkg/node"
+	"github.com/henderiw-nephio/network-node-operator/pkg/node/srlinux"
+	invv1alpha1 "github.com/nokia/k8s-ipam/apis/inv/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	crwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+const (
+	testPodNamespace = "network-system"
+	testNamespace    = "default"
+)
+
+// TestWebhooks runs the webhooks against the api server of envtest, the webhook
+// configurations are read from the blueprint. The test is skipped when the envtest
+// binaries are not available, e.g. use make test.
+func TestWebhooks(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set")
+	}
+	t.Setenv("POD_NAMESPACE", testPodNamespace)
+
+	testEnv := &envtest.Environment{
+		CRDs: []*apiextensionsv1.CustomResourceDefinition{
+			getTestCRD(invv1alpha1.NodeKind, "nodes"),
+			getTestCRD(invv1alpha1.NodeConfigKind, "nodeconfigs"),
+		},
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join("..", "..", "blueprint", "network-node", "app", "webhook")},
+		},
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := testEnv.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	wio := testEnv.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:             s,
+		MetricsBindAddress: "0",
+		WebhookServer: crwebhook.NewServer(crwebhook.Options{
+			Host:    wio.LocalServingHost,
+			Port:    wio.LocalServingPort,
+			CertDir: wio.LocalServingCertDir,
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nr := node.NewNodeRegistry()
+	srlinux.Register(nr)
+	if err := Setup(mgr, nr); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			t.Error(err)
+		}
+	}()
+	waitForWebhookServer(t, fmt.Sprintf("%s:%d", wio.LocalServingHost, wio.LocalServingPort))
+
+	c, err := client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testPodNamespace}},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "srlinux.nokia.com-variants"},
+			Data:       map[string]string{"ixrd3l": "", "ixrd2l": ""},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "licenses.srl.nokia.com"},
+			Data:       map[string][]byte{"lic1": []byte("license")},
+		},
+	} {
+		if err := c.Create(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the steps build on each other and run in order
+	steps := []struct {
+		name    string
+		obj     client.Object
+		wantErr string
+	}{
+		{
+			name:    "NodeUnknownProvider",
+			obj:     getTestNode("leaf0", "unknown.provider.com", "", nil),
+			wantErr: "is not supported",
+		},
+		{
+			name: "NodeConfigDefault",
+			obj:  getTestNodeConfig("default", nil),
+		},
+		{
+			name:    "NodeMissingNodeConfig",
+			obj:     getTestNode("leaf0", srlinux.NokiaSRLinuxProvider, "missing", nil),
+			wantErr: "not found",
+		},
+		{
+			name: "NodeConfigUnknownModelWithoutNodes",
+			obj: getTestNodeConfig("leaf1", func(nc *invv1alpha1.NodeConfig) {
+				nc.Spec.Model = pointer.String("ixr-unknown")
+			}),
+		},
+		{
+			name:    "NodeUnknownModel",
+			obj:     getTestNode("leaf1", srlinux.NokiaSRLinuxProvider, "", nil),
+			wantErr: "is not a variant",
+		},
+		{
+			name: "NodeConfigSelectorUnknownLicenseKey",
+			obj: getTestNodeConfig("spines", func(nc *invv1alpha1.NodeConfig) {
+				nc.SetAnnotations(map[string]string{node.NodeSelectorKey: "role=spine"})
+				nc.Spec.LicenseKey = pointer.String("lic2")
+			}),
+		},
+		{
+			name:    "NodeUnknownLicenseKey",
+			obj:     getTestNode("spine1", srlinux.NokiaSRLinuxProvider, "", map[string]string{"role": "spine"}),
+			wantErr: "license key",
+		},
+		{
+			name: "NodeValid",
+			obj:  getTestNode("leaf2", srlinux.NokiaSRLinuxProvider, "", nil),
+		},
+		{
+			name: "NodeConfigInvalidSelector",
+			obj: getTestNodeConfig("leaves", func(nc *invv1alpha1.NodeConfig) {
+				nc.SetAnnotations(map[string]string{node.NodeSelectorKey: "role in ("})
+			}),
+			wantErr: node.NodeSelectorKey,
+		},
+		{
+			name: "NodeConfigUnknownModelOfNode",
+			obj: getTestNodeConfig("leaf2", func(nc *invv1alpha1.NodeConfig) {
+				nc.Spec.Model = pointer.String("ixr-unknown")
+			}),
+			wantErr: "for node default/leaf2",
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			// the webhooks read through the cache of the manager, so the objects of
+			// the previous steps might not be visible immediately
+			var err error
+			for i := 0; i < 20; i++ {
+				obj := step.obj.DeepCopyObject().(client.Object)
+				err = c.Create(ctx, obj)
+				if (step.wantErr == "" && err == nil) || (step.wantErr != "" && err != nil && strings.Contains(err.Error(), step.wantErr)) {
+					return
+				}
+				if err == nil {
+					// admitted before the webhook observed the objects of the previous steps
+					if err := c.Delete(ctx, obj); err != nil {
+						t.Fatal(err)
+					}
+				}
+				time.Sleep(500 * time.Millisecond)
+			}
+			if step.wantErr == "" {
+				t.Errorf("want no error, got: %s", err)
+				return
+			}
+			t.Errorf("want error containing %q, got: %v", step.wantErr, err)
+		})
+	}
+
+	nc := &invv1alpha1.NodeConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: testPodNamespace, Name: "default"}, nc); err != nil {
+		t.Fatal(err)
+	}
+	if nc.Spec.Model == nil || nc.Spec.Image == nil {
+		t.Errorf("want the model and the image of the default node config to be defaulted, got: %v", nc.Spec)
+	}
+}
+
+func getTestNode(name, provider, nodeConfig string, labels map[string]string) *invv1alpha1.Node {
+	cr := &invv1alpha1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: invv1alpha1.NodeSpec{
+			Provider: provider,
+		},
+	}
+	if nodeConfig != "" {
+		cr.Spec.NodeConfig = &invv1alpha1.NodeConfigInfo{Name: nodeConfig}
+	}
+	return cr
+}
+
+func getTestNodeConfig(name string, mutate func(nc *invv1alpha1.NodeConfig)) *invv1alpha1.NodeConfig {
+	nc := &invv1alpha1.NodeConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testPodNamespace,
+			Name:      name,
+		},
+		Spec: invv1alpha1.NodeConfigSpec{
+			Provider: srlinux.NokiaSRLinuxProvider,
+		},
+	}
+	if mutate != nil {
+		mutate(nc)
+	}
+	return nc
+}
+
+// getTestCRD returns a crd of the inventory api without a schema, the crds are
+// owned by the ipam and are not part of this repo.
+func getTestCRD(kind, plural string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s.%s", plural, invv1alpha1.Group),
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: invv1alpha1.Group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:     kind,
+				ListKind: kind + "List",
+				Plural:   plural,
+				Singular: strings.ToLower(kind),
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    invv1alpha1.Version,
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type:                   "object",
+						XPreserveUnknownFields: pointer.Bool(true),
+					},
+				},
+				Subresources: &apiextensionsv1.CustomResourceSubresources{
+					Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+				},
+			}},
+		},
+	}
+}
+
+func waitForWebhookServer(t *testing.T, addr string) {
+	dialer := &net.Dialer{Timeout: time.Second}
+	for i := 0; i < 20; i++ {
+		conn, err := tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{InsecureSkipVerify: true}) //nolint:gosec
+		if err == nil {
+			conn.Close()
+			return
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	t.Fatalf("webhook server %s is not serving", addr)
+}